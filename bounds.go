@@ -0,0 +1,30 @@
+package guid
+
+import (
+	"time"
+)
+
+// MinForTime returns the smallest canonical GUID with the given prefix
+// whose timestamp falls in the same millisecond as t. Every GUID with that
+// prefix created at or after t sorts at or after it, both by Compare and
+// by its string form, which makes it a lower bound for range queries on
+// indexed GUID columns.
+func MinForTime(t time.Time, prefix [2]byte) GUID {
+	return (GUID{prefix[0], prefix[1]}).
+		SetTime(t).
+		SetFingerprint(0).
+		SetCounter(0).
+		SetRandom(0)
+}
+
+// MaxForTime returns the largest canonical GUID with the given prefix
+// whose timestamp falls in the same millisecond as t. Every GUID with that
+// prefix created at or before t sorts at or before it, which makes it an
+// upper bound for range queries on indexed GUID columns.
+func MaxForTime(t time.Time, prefix [2]byte) GUID {
+	return (GUID{prefix[0], prefix[1]}).
+		SetTime(t).
+		SetFingerprint(maxInt - 1).
+		SetCounter(maxInt - 1).
+		SetRandom(maxRandom - 1)
+}
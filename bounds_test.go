@@ -0,0 +1,76 @@
+package guid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeBounds(t *testing.T) {
+	t1 := time.Unix(0, 1600000000123456789)
+	t2 := t1.Add(50 * time.Millisecond)
+	prefix := [2]byte{'u', 's'}
+
+	lower := MinForTime(t1, prefix).String()
+	upper := MaxForTime(t2, prefix).String()
+
+	if lower[:2] != "us" || lower[tsEnd:] != "000000000000000000" {
+		t.Fatalf("expected lower bound to zero every field after the timestamp, got %s", lower)
+	}
+	if upper[tsEnd:] != "zzzzzzzzzzzzzzzzzz" {
+		t.Fatalf("expected upper bound to max out every field after the timestamp, got %s", upper)
+	}
+
+	// generate GUIDs across the whole range, including the edges
+	now := t1
+	gen := MustNewGenerator(
+		WithGeneratorPrefix('u', 's'),
+		WithClock(func() time.Time { return now }),
+	)
+	for now = t1; !now.After(t2); now = now.Add(time.Millisecond) {
+		g, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := g.String()
+		if s < lower || s > upper {
+			t.Fatalf("expected %s to fall within [%s, %s]", s, lower, upper)
+		}
+		if Compare(g, MinForTime(t1, prefix)) < 0 || Compare(g, MaxForTime(t2, prefix)) > 0 {
+			t.Fatalf("expected %s to compare within bounds", s)
+		}
+	}
+
+	// GUIDs outside of the range fall outside of the bounds
+	now = t1.Add(-time.Millisecond)
+	before, err := gen.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.String() >= lower {
+		t.Fatalf("expected %s to sort before %s", before, lower)
+	}
+	now = t2.Add(time.Millisecond)
+	after, err := gen.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.String() <= upper {
+		t.Fatalf("expected %s to sort after %s", after, upper)
+	}
+}
+
+func TestTimeBoundsRoundTrip(t *testing.T) {
+	ts := time.UnixMilli(1600000000123)
+	for _, g := range []GUID{MinForTime(ts, [2]byte{'i', 'd'}), MaxForTime(ts, [2]byte{'i', 'd'})} {
+		if !g.Time().Equal(ts) {
+			t.Fatalf("expected time %v, got %v", ts, g.Time())
+		}
+		g2, err := ParseStringStrict(g.String())
+		if err != nil {
+			t.Fatalf("expected bound %s to be canonical: %v", g, err)
+		}
+		if g2 != g {
+			t.Fatalf("round trip mismatch: %s != %s", g2, g)
+		}
+	}
+}
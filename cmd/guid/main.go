@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -9,7 +10,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/schigh/guid"
@@ -24,29 +24,81 @@ var (
 	slug     bool
 	scan     string
 	scanJSON bool
+	kinds    string
+	format   string
+	encoding string
+	short    bool
 )
 
 const (
 	nl     = "--newline--"
 	stdout = "--stdout--"
+
+	formatGUID   = "guid"
+	formatUUIDv7 = "uuidv7"
 )
 
+// encodings are the guid text encodings selectable with -encoding
+var encodings = map[string]guid.Encoding{
+	"base36":      guid.Base36Encoding,
+	"crockford32": guid.Crockford32Encoding,
+	"base62":      guid.Base62Encoding,
+	"hex":         guid.HexEncoding,
+}
+
 func main() {
 	flag.StringVar(&prefix, "p", "", "guid prefix")
 	flag.UintVar(&times, "n", 1, "number of guids to generate")
 	flag.StringVar(&sep, "sep", nl, "separator for multiple guids")
-	flag.BoolVar(&serial, "serial", false, "generate all guids serially")
+	flag.BoolVar(&serial, "serial", false, "generate guids one at a time instead of in a batch")
 	flag.StringVar(&dest, "o", stdout, "output file")
 	flag.BoolVar(&slug, "slug", false, "output a slug instead of a full guid")
 	flag.StringVar(&scan, "scan", "", "inspect guid and print parts to console")
 	flag.BoolVar(&scanJSON, "json", false, "sets the output of SCAN to json")
+	flag.StringVar(&kinds, "kinds", "", "comma-separated prefix=name pairs used by SCAN to name the kind of a guid")
+	flag.StringVar(&format, "format", formatGUID, "output format: guid or uuidv7")
+	flag.StringVar(&encoding, "encoding", "base36", "guid text encoding for output and SCAN: base36, crockford32, base62 or hex")
+	flag.BoolVar(&short, "short", false, "output reversible short ids instead of full guids; with SCAN, decode a short id")
 	flag.Parse()
 
+	enc, ok := encodings[encoding]
+	if !ok {
+		log.Fatalf("unknown encoding '%s'", encoding)
+	}
+	if short {
+		if enc != guid.Base36Encoding {
+			log.Fatal("-short cannot be combined with -encoding")
+		}
+		enc = guid.ShortEncoding
+	}
+
+	if kinds != "" {
+		if err := registerKinds(kinds); err != nil {
+			log.Fatalf("invalid kinds: %v", err)
+		}
+	}
+
 	if scan != "" {
-		scanGUID(scan, scanJSON)
+		scanGUID(scan, scanJSON, enc)
 		return
 	}
 
+	switch format {
+	case formatGUID:
+		if slug && enc != guid.Base36Encoding {
+			log.Fatal("-slug cannot be combined with -encoding or -short")
+		}
+	case formatUUIDv7:
+		if slug {
+			log.Fatalf("-slug is not supported with -format %s", formatUUIDv7)
+		}
+		if enc != guid.Base36Encoding {
+			log.Fatalf("-encoding and -short are not supported with -format %s", formatUUIDv7)
+		}
+	default:
+		log.Fatalf("unknown format '%s'", format)
+	}
+
 	var writeTo io.WriteCloser = os.Stdout
 
 	// determine where the guids will go
@@ -79,21 +131,29 @@ func main() {
 		}
 	}
 
-	var guids []guid.GUID
+	var guidStrs []string
 
-	if serial {
-		guids = generateSerially(times)
+	if format == formatUUIDv7 {
+		guidStrs = generateUUIDs(times)
 	} else {
-		guids = generateAsync(times)
-	}
+		var guids []guid.GUID
+		if serial {
+			guids = generateSerially(times)
+		} else {
+			guids = generateBatch(times)
+		}
 
-	guidStrs := make([]string, len(guids))
-	for i := range guids {
-		if slug {
-			guidStrs[i] = guids[i].Slug()
-			continue
+		guidStrs = make([]string, len(guids))
+		for i := range guids {
+			if slug {
+				guidStrs[i] = guids[i].Slug()
+				continue
+			}
+			var err error
+			if guidStrs[i], err = enc.EncodeToString(guids[i]); err != nil {
+				log.Fatalf("encode error: %v", err)
+			}
 		}
-		guidStrs[i] = guids[i].String()
 	}
 
 	if sep == nl {
@@ -106,22 +166,106 @@ func main() {
 	}
 }
 
-func scanGUID(s string, isJSON bool) {
-	const (
-		green   = "\u001b[0;32m"
-		nocolor = "\u001b[0m"
-	)
-	g, err := guid.ParseString(s)
+const (
+	green   = "\u001b[0;32m"
+	nocolor = "\u001b[0m"
+)
+
+func scanGUID(s string, isJSON bool, enc guid.Encoding) {
+	if u, err := guid.ParseUUID(s); err == nil {
+		scanUUID(u, isJSON)
+		return
+	}
+
+	g, err := enc.DecodeString(s)
 	if err != nil {
+		var pe *guid.ParseError
+		hasDetail := errors.As(err, &pe)
 		if isJSON {
-			_, _ = fmt.Fprintf(os.Stderr, `{"error":"Parse GUID failed. '%s' is not a valid guid. Only a full guid can be scanned."}`, s)
+			out := map[string]any{
+				"error": fmt.Sprintf("Parse GUID failed. '%s' is not a valid guid. Only a full guid can be scanned.", s),
+			}
+			if hasDetail {
+				out["reason"] = pe.Err.Error()
+				out["offset"] = pe.Offset
+				if pe.Field != "" {
+					out["field"] = pe.Field
+				}
+			} else {
+				out["reason"] = err.Error()
+			}
+			data, _ := json.Marshal(out)
+			_, _ = os.Stderr.Write(data)
 			return
 		}
 		_, _ = fmt.Fprintf(os.Stderr, "Parse GUID failed\n'%s' is not a valid guid\nOnly a full guid can be scanned.\n", s)
+		if hasDetail && pe.Field != "" {
+			_, _ = fmt.Fprintf(os.Stderr, "invalid %s '%s' at offset %d: %v\n", pe.Field, pe.Input, pe.Offset, pe.Err)
+		} else {
+			_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
 		os.Exit(1)
 	}
 
+	printGUID(g, isJSON)
+}
+
+// scanUUID prints the parts of a UUIDv7, or of a UUIDv8 converted from a guid
+// with guid.GUID.LossyUUID
+func scanUUID(u guid.UUID, isJSON bool) {
+	switch u.Version() {
+	case 7:
+		f, err := u.V7()
+		if err != nil {
+			scanUUIDFailed(u, err, isJSON)
+			return
+		}
+		if isJSON {
+			out := map[string]string{
+				"version":     "7",
+				"timestamp":   f.Time.Format(time.ANSIC),
+				"counter":     fmt.Sprintf("%d", f.Counter),
+				"fingerprint": fmt.Sprintf("%d", f.Fingerprint),
+				"random":      fmt.Sprintf("%d", f.Random),
+			}
+			data, _ := json.Marshal(out)
+			_, _ = os.Stdout.Write(data)
+			return
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "%sVERSION%s:     7\n", green, nocolor)
+		_, _ = fmt.Fprintf(os.Stderr, "%sTIMESTAMP%s:   %s\n", green, nocolor, f.Time.Format(time.ANSIC))
+		_, _ = fmt.Fprintf(os.Stderr, "%sCOUNTER%s:     %d\n", green, nocolor, f.Counter)
+		_, _ = fmt.Fprintf(os.Stderr, "%sFINGERPRINT%s: %d\n", green, nocolor, f.Fingerprint)
+		_, _ = fmt.Fprintf(os.Stderr, "%sRANDOM%s:      %d\n", green, nocolor, f.Random)
+	case 8:
+		g, err := guid.FromLossyUUID(u)
+		if err != nil {
+			scanUUIDFailed(u, err, isJSON)
+			return
+		}
+		printLossyGUID(g, isJSON)
+	default:
+		scanUUIDFailed(u, fmt.Errorf("unsupported UUID version %d", u.Version()), isJSON)
+	}
+}
+
+func scanUUIDFailed(u guid.UUID, err error, isJSON bool) {
+	if isJSON {
+		data, _ := json.Marshal(map[string]any{
+			"error":  fmt.Sprintf("Scan UUID failed. '%s' cannot be decomposed.", u),
+			"reason": err.Error(),
+		})
+		_, _ = os.Stderr.Write(data)
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "Scan UUID failed\n'%s' cannot be decomposed: %v\n", u, err)
+	os.Exit(1)
+}
+
+// printGUID prints the parts of a guid
+func printGUID(g guid.GUID, isJSON bool) {
 	p1, p2 := g.PrefixBytes()
+	kind, hasKind := g.Kind()
 
 	if isJSON {
 		out := map[string]string{
@@ -131,18 +275,76 @@ func scanGUID(s string, isJSON bool) {
 			"counter":     fmt.Sprintf("%d", g.Counter()),
 			"random":      fmt.Sprintf("%d", g.Random()),
 		}
+		if hasKind {
+			out["kind"] = kind.Name
+		}
 		data, _ := json.Marshal(out)
 		_, _ = os.Stdout.Write(data)
 		return
 	}
 
 	_, _ = fmt.Fprintf(os.Stderr, "%sPREFIX%s:      %s\n", green, nocolor, string([]byte{p1, p2}))
+	if hasKind {
+		_, _ = fmt.Fprintf(os.Stderr, "%sKIND%s:        %s\n", green, nocolor, kind.Name)
+	}
 	_, _ = fmt.Fprintf(os.Stderr, "%sTIMESTAMP%s:   %s\n", green, nocolor, g.Time().Format(time.ANSIC))
 	_, _ = fmt.Fprintf(os.Stderr, "%sFINGERPRINT%s: %d\n", green, nocolor, g.Fingerprint())
 	_, _ = fmt.Fprintf(os.Stderr, "%sCOUNTER%s:     %d\n", green, nocolor, g.Counter())
 	_, _ = fmt.Fprintf(os.Stderr, "%sRANDOM%s:      %d\n", green, nocolor, g.Random())
 }
 
+// printLossyGUID prints the parts of a guid recovered from a UUIDv8. Only
+// the low bits of the original random field survive the conversion, so
+// the guid itself is not printed and the random field is labeled as such.
+func printLossyGUID(g guid.GUID, isJSON bool) {
+	p1, p2 := g.PrefixBytes()
+	kind, hasKind := g.Kind()
+
+	if isJSON {
+		out := map[string]string{
+			"version":         "8",
+			"lossy":           "true",
+			"prefix":          string([]byte{p1, p2}),
+			"timestamp":       g.Time().Format(time.ANSIC),
+			"fingerprint":     fmt.Sprintf("%d", g.Fingerprint()),
+			"counter":         fmt.Sprintf("%d", g.Counter()),
+			"random_low_bits": fmt.Sprintf("%d", g.Random()),
+		}
+		if hasKind {
+			out["kind"] = kind.Name
+		}
+		data, _ := json.Marshal(out)
+		_, _ = os.Stdout.Write(data)
+		return
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "%sVERSION%s:     8 (lossy guid conversion)\n", green, nocolor)
+	_, _ = fmt.Fprintf(os.Stderr, "%sPREFIX%s:      %s\n", green, nocolor, string([]byte{p1, p2}))
+	if hasKind {
+		_, _ = fmt.Fprintf(os.Stderr, "%sKIND%s:        %s\n", green, nocolor, kind.Name)
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "%sTIMESTAMP%s:   %s\n", green, nocolor, g.Time().Format(time.ANSIC))
+	_, _ = fmt.Fprintf(os.Stderr, "%sFINGERPRINT%s: %d\n", green, nocolor, g.Fingerprint())
+	_, _ = fmt.Fprintf(os.Stderr, "%sCOUNTER%s:     %d\n", green, nocolor, g.Counter())
+	_, _ = fmt.Fprintf(os.Stderr, "%sRANDOM%s:      %d (low 20 bits only)\n", green, nocolor, g.Random())
+}
+
+// registerKinds registers comma-separated prefix=name pairs
+// with the default prefix registry
+func registerKinds(in string) error {
+	for _, pair := range strings.Split(in, ",") {
+		pfx, name, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || len(pfx) != 2 {
+			return fmt.Errorf("'%s' is not a prefix=name pair", pair)
+		}
+		if err := guid.RegisterPrefix(pfx[0], pfx[1], name, ""); err != nil {
+			return err
+		}
+	}
+	guid.DefaultPrefixRegistry.Freeze()
+	return nil
+}
+
 func normalizeRelativePath(in string) string {
 	pwd, err := os.Getwd()
 	if err != nil {
@@ -169,16 +371,26 @@ func generateSerially(n uint) []guid.GUID {
 	return buffer
 }
 
-func generateAsync(n uint) []guid.GUID {
-	buffer := make([]guid.GUID, n)
-	var wg sync.WaitGroup
-	for i := uint(0); i < n; i++ {
-		wg.Add(1)
-		go func(idx uint) {
-			defer wg.Done()
-			buffer[idx] = guid.MustNew()
-		}(i)
-	}
-	wg.Wait()
+func generateUUIDs(n uint) []string {
+	gen := guid.MustNewUUIDv7Generator()
+	buffer := make([]string, 0, n)
+	var i uint
+	for i < n {
+		u, err := gen.GenerateUUID()
+		if err != nil {
+			log.Fatalf("generate error: %v", err)
+		}
+		buffer = append(buffer, u.String())
+		i++
+	}
+
+	return buffer
+}
+
+func generateBatch(n uint) []guid.GUID {
+	buffer, err := guid.NewBatch(int(n))
+	if err != nil {
+		log.Fatalf("generate error: %v", err)
+	}
 	return buffer
 }
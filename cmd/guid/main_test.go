@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -220,3 +221,229 @@ func TestZeroCount(t *testing.T) {
 		t.Fatalf("expected 1 GUID, got output length %d", len(stdout))
 	}
 }
+
+func TestScanInvalidGUIDDetail(t *testing.T) {
+	_, stderr, code := runBinary(t, "-scan", "xo00000000!00000000000000000")
+	if code == 0 {
+		t.Fatal("expected non-zero exit code for invalid GUID scan")
+	}
+	if !strings.Contains(stderr, "invalid fingerprint") || !strings.Contains(stderr, "offset 10") {
+		t.Fatalf("expected field and offset in scan output, got: %q", stderr)
+	}
+
+	_, stderr, _ = runBinary(t, "-scan", "xo00000000!00000000000000000", "-json")
+	var result map[string]any
+	if err := json.Unmarshal([]byte(stderr), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\nraw: %q", err, stderr)
+	}
+	if result["field"] != "fingerprint" {
+		t.Fatalf("expected field 'fingerprint', got %v", result["field"])
+	}
+	if result["offset"] != float64(10) {
+		t.Fatalf("expected offset 10, got %v", result["offset"])
+	}
+}
+
+func TestScanKind(t *testing.T) {
+	genOut, _, code := runBinary(t, "-p", "us")
+	if code != 0 {
+		t.Fatal("generation failed")
+	}
+	guidStr := strings.TrimSpace(genOut)
+
+	_, stderr, code := runBinary(t, "-scan", guidStr, "-kinds", "or=order,us=user")
+	if code != 0 {
+		t.Fatalf("scan failed with exit code %d", code)
+	}
+	if !strings.Contains(stderr, "KIND") || !strings.Contains(stderr, "user") {
+		t.Fatalf("expected kind in scan output, got: %q", stderr)
+	}
+
+	stdout, _, code := runBinary(t, "-scan", guidStr, "-kinds", "us=user", "-json")
+	if code != 0 {
+		t.Fatalf("scan failed with exit code %d", code)
+	}
+	var result map[string]string
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\nraw: %q", err, stdout)
+	}
+	if result["kind"] != "user" {
+		t.Fatalf("expected kind 'user', got %q", result["kind"])
+	}
+
+	_, _, code = runBinary(t, "-scan", guidStr, "-kinds", "us=user,us=other")
+	if code == 0 {
+		t.Fatal("expected non-zero exit code for duplicate kinds")
+	}
+}
+
+func TestUUIDv7Format(t *testing.T) {
+	stdout, _, code := runBinary(t, "-format", "uuidv7", "-n", "3")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 UUIDs, got %d", len(lines))
+	}
+	for i, line := range lines {
+		u, err := guid.ParseUUID(line)
+		if err != nil {
+			t.Fatalf("output is not a valid UUID: %v", err)
+		}
+		if u.Version() != 7 {
+			t.Fatalf("expected version 7, got %d", u.Version())
+		}
+		if i > 0 && line <= lines[i-1] {
+			t.Fatalf("expected %s > %s", line, lines[i-1])
+		}
+	}
+
+	if _, _, code := runBinary(t, "-format", "uuidv7", "-slug"); code == 0 {
+		t.Fatal("expected non-zero exit code for -slug with uuidv7")
+	}
+	if _, _, code := runBinary(t, "-format", "nope"); code == 0 {
+		t.Fatal("expected non-zero exit code for an unknown format")
+	}
+}
+
+func TestScanUUID(t *testing.T) {
+	genOut, _, code := runBinary(t, "-format", "uuidv7")
+	if code != 0 {
+		t.Fatal("generation failed")
+	}
+	uuidStr := strings.TrimSpace(genOut)
+
+	_, stderr, code := runBinary(t, "-scan", uuidStr)
+	if code != 0 {
+		t.Fatalf("scan failed with exit code %d", code)
+	}
+	for _, label := range []string{"VERSION", "TIMESTAMP", "COUNTER", "FINGERPRINT", "RANDOM"} {
+		if !strings.Contains(stderr, label) {
+			t.Fatalf("expected %q in scan output, got: %q", label, stderr)
+		}
+	}
+
+	stdout, _, code := runBinary(t, "-scan", uuidStr, "-json")
+	if code != 0 {
+		t.Fatalf("scan failed with exit code %d", code)
+	}
+	var result map[string]string
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\nraw: %q", err, stdout)
+	}
+	if result["version"] != "7" {
+		t.Fatalf("expected version 7, got %q", result["version"])
+	}
+
+	// a UUIDv8 converted from a guid decomposes into the surviving parts
+	u, err := guid.TestGUID.LossyUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, stderr, code = runBinary(t, "-scan", u.String())
+	if code != 0 {
+		t.Fatalf("scan failed with exit code %d", code)
+	}
+	if !strings.Contains(stderr, "PREFIX") || !strings.Contains(stderr, "te") {
+		t.Fatalf("expected the guid prefix in scan output, got: %q", stderr)
+	}
+	if !strings.Contains(stderr, "lossy") || !strings.Contains(stderr, "low 20 bits only") {
+		t.Fatalf("expected the scan output to flag the lossy conversion, got: %q", stderr)
+	}
+
+	stdout, _, code = runBinary(t, "-scan", u.String(), "-json")
+	if code != 0 {
+		t.Fatalf("scan failed with exit code %d", code)
+	}
+	result = nil
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\nraw: %q", err, stdout)
+	}
+	if result["lossy"] != "true" || result["prefix"] != "te" {
+		t.Fatalf("expected a lossy result with prefix te, got %v", result)
+	}
+	if _, ok := result["random"]; ok {
+		t.Fatalf("expected no full random field, got %v", result)
+	}
+
+	if _, _, code := runBinary(t, "-scan", "f47ac10b-58cc-4372-a567-0e02b2c3d479"); code == 0 {
+		t.Fatal("expected non-zero exit code for a UUIDv4")
+	}
+}
+
+func TestEncodingFlag(t *testing.T) {
+	for name, enc := range map[string]guid.Encoding{
+		"crockford32": guid.Crockford32Encoding,
+		"base62":      guid.Base62Encoding,
+		"hex":         guid.HexEncoding,
+	} {
+		t.Run(name, func(t *testing.T) {
+			stdout, _, code := runBinary(t, "-encoding", name, "-p", "us")
+			if code != 0 {
+				t.Fatalf("expected exit code 0, got %d", code)
+			}
+			encoded := strings.TrimSpace(stdout)
+			g, err := enc.DecodeString(encoded)
+			if err != nil {
+				t.Fatalf("output is not a valid %s guid: %v", name, err)
+			}
+			if p1, p2 := g.PrefixBytes(); p1 != 'u' || p2 != 's' {
+				t.Fatalf("expected prefix 'us', got %c%c", p1, p2)
+			}
+
+			stdout, _, code = runBinary(t, "-scan", encoded, "-encoding", name, "-json")
+			if code != 0 {
+				t.Fatalf("scan failed with exit code %d", code)
+			}
+			var result map[string]string
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("invalid JSON output: %v\nraw: %q", err, stdout)
+			}
+			if result["prefix"] != "us" || result["random"] != fmt.Sprintf("%d", g.Random()) {
+				t.Fatalf("unexpected scan output %v", result)
+			}
+		})
+	}
+
+	if _, _, code := runBinary(t, "-encoding", "nope"); code == 0 {
+		t.Fatal("expected non-zero exit code for an unknown encoding")
+	}
+	if _, stderr, code := runBinary(t, "-scan", "not-base62", "-encoding", "base62"); code == 0 || !strings.Contains(stderr, "invalid length") {
+		t.Fatalf("expected the decode error in scan output, got %d: %q", code, stderr)
+	}
+}
+
+func TestShortFlag(t *testing.T) {
+	stdout, _, code := runBinary(t, "-short", "-n", "2", "-p", "us")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || len(lines[0]) != 25 {
+		t.Fatalf("expected 2 short ids, got %q", stdout)
+	}
+	g, err := guid.ParseShort(lines[0])
+	if err != nil {
+		t.Fatalf("output is not a valid short id: %v", err)
+	}
+
+	stdout, _, code = runBinary(t, "-scan", lines[0], "-short", "-json")
+	if code != 0 {
+		t.Fatalf("scan failed with exit code %d", code)
+	}
+	var result map[string]string
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\nraw: %q", err, stdout)
+	}
+	if result["prefix"] != "us" || result["counter"] != fmt.Sprintf("%d", g.Counter()) {
+		t.Fatalf("unexpected scan output %v", result)
+	}
+
+	if _, _, code := runBinary(t, "-short", "-encoding", "hex"); code == 0 {
+		t.Fatal("expected non-zero exit code for -short with -encoding")
+	}
+	if _, _, code := runBinary(t, "-short", "-slug"); code == 0 {
+		t.Fatal("expected non-zero exit code for -short with -slug")
+	}
+}
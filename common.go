@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"sync"
 	"sync/atomic"
 )
@@ -38,10 +39,16 @@ var (
 // Prefix bytes must be lowercase base36 characters (0-9, a-z).
 // Invalid bytes return an error without consuming the one-shot,
 // so a subsequent call with valid bytes will still succeed.
+// Calls made after LockGlobals return ErrGlobalsLocked.
 func SetGlobalPrefixBytes(b1, b2 byte) error {
 	if !(isValidPrefixByte(b1) && isValidPrefixByte(b2)) {
 		return fmt.Errorf("guid.SetGlobalPrefixBytes: prefix bytes must be base36-compatible and lowercase")
 	}
+	globalsMu.Lock()
+	defer globalsMu.Unlock()
+	if globalsLocked {
+		return fmt.Errorf("guid.SetGlobalPrefixBytes: %w", ErrGlobalsLocked)
+	}
 	prefixOnce.Do(func() {
 		globalPrefix.Store([2]byte{b1, b2})
 	})
@@ -102,6 +109,95 @@ func leftPad(in string, size int) string {
 	return string(b)
 }
 
+// base36 digits, in ascending order
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// appendBase36 appends v to dst as a base36 string left-padded with
+// zeros to the given width. Values that do not fit in the width fall
+// back to the variable-width encoding produced by strconv.
+func appendBase36(dst []byte, v int64, width int) []byte {
+	limit := int64(1)
+	for i := 0; i < width; i++ {
+		limit *= base
+	}
+	if v < 0 || v >= limit {
+		return append(dst, leftPad(strconv.FormatInt(v, base), width)...)
+	}
+
+	n := len(dst) + width
+	for i := 0; i < width; i++ {
+		dst = append(dst, '0')
+	}
+	for i := n - 1; v > 0; i-- {
+		dst[i] = digits[v%base]
+		v /= base
+	}
+
+	return dst
+}
+
+// invalidDigit marks bytes that are not base36 digits
+const invalidDigit = 0xff
+
+// base36Values maps ASCII bytes to their base36 digit values. Uppercase
+// letters are accepted, matching strconv.ParseUint.
+var base36Values = func() (m [256]byte) {
+	for i := range m {
+		m[i] = invalidDigit
+	}
+	for i := 0; i < base; i++ {
+		m[digits[i]] = byte(i)
+		if digits[i] >= 'a' {
+			m[digits[i]-'a'+'A'] = byte(i)
+		}
+	}
+	return m
+}()
+
+// base36LowerValues maps ASCII bytes to their base36 digit values,
+// accepting only the canonical lowercase digits.
+var base36LowerValues = func() (m [256]byte) {
+	for i := range m {
+		m[i] = invalidDigit
+	}
+	for i := 0; i < base; i++ {
+		m[digits[i]] = byte(i)
+	}
+	return m
+}()
+
+// decodeBase36 decodes a base36 field of at most 12 digits using the
+// given digit table. It returns the index of the first invalid digit,
+// or -1 if the field is valid.
+func decodeBase36[T string | []byte](in T, values *[256]byte) (int64, int) {
+	var v int64
+	for i := 0; i < len(in); i++ {
+		d := values[in[i]]
+		if d == invalidDigit {
+			return 0, i
+		}
+		v = v*base + int64(d)
+	}
+	return v, -1
+}
+
+// putUintBE writes the low len(b) bytes of v into b in big-endian order
+func putUintBE(b []byte, v uint64) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+}
+
+// uintBE reads a big-endian unsigned integer of up to 8 bytes
+func uintBE(b []byte) uint64 {
+	var v uint64
+	for i := range b {
+		v = v<<8 | uint64(b[i])
+	}
+	return v
+}
+
 // get the default hostname of the device
 func defaultHostname() int32 {
 	h, err := os.Hostname()
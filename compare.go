@@ -0,0 +1,54 @@
+package guid
+
+import (
+	"cmp"
+)
+
+// Nil is the zero value GUID.
+var Nil GUID
+
+// IsZero reports whether the GUID is the zero value.
+func (g GUID) IsZero() bool {
+	return g == Nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether a sorts before, equal
+// to or after b. The ordering matches the lexical ordering of the
+// canonical strings, so GUIDs that share a prefix are ordered by their
+// embedded timestamp. Compare can be passed directly to slices.SortFunc.
+func Compare(a, b GUID) int {
+	if c := cmp.Compare(a[0], b[0]); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a[1], b[1]); c != 0 {
+		return c
+	}
+
+	ats, afp, act, ard := a.fields()
+	bts, bfp, bct, brd := b.fields()
+	if c := cmp.Compare(ats, bts); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(afp, bfp); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(act, bct); c != 0 {
+		return c
+	}
+	return cmp.Compare(ard, brd)
+}
+
+// Compare compares the GUID with o. See the Compare function.
+func (g GUID) Compare(o GUID) int {
+	return Compare(g, o)
+}
+
+// Less reports whether the GUID sorts before o.
+func (g GUID) Less(o GUID) bool {
+	return Compare(g, o) < 0
+}
+
+// Equal reports whether the GUID is equal to o.
+func (g GUID) Equal(o GUID) bool {
+	return g == o
+}
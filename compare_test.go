@@ -0,0 +1,115 @@
+package guid
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// randomGUID builds a canonical GUID with random fields. Fields are drawn
+// from small ranges so that ties on leading fields are common.
+func randomGUID(rando *rand.Rand) GUID {
+	pfx := []byte("ab")
+	g := GUID{pfx[rando.Intn(len(pfx))], pfx[rando.Intn(len(pfx))]}
+	return g.
+		SetTime(time.UnixMilli(1600000000000 + rando.Int63n(4))).
+		SetFingerprint(rando.Int31n(3)).
+		SetCounter(rando.Int31n(3)).
+		SetRandom(rando.Int63n(maxRandom))
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
+
+func TestCompare(t *testing.T) {
+	rando := rand.New(rand.NewSource(1622222222222000000))
+
+	for i := 0; i < 10000; i++ {
+		a, b := randomGUID(rando), randomGUID(rando)
+		c := Compare(a, b)
+
+		// string form
+		if s := strings.Compare(a.String(), b.String()); sign(s) != c {
+			t.Fatalf("Compare(%s, %s) = %d, string comparison = %d", a, b, c, s)
+		}
+
+		// binary form
+		ab, err := a.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		bb, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bc := bytes.Compare(ab, bb); bc != c {
+			t.Fatalf("Compare(%s, %s) = %d, binary comparison = %d", a, b, c, bc)
+		}
+
+		// embedded timestamp
+		if a[0] == b[0] && a[1] == b[1] {
+			if a.Time().Before(b.Time()) && c >= 0 {
+				t.Fatalf("expected %s to sort before %s", a, b)
+			}
+			if a.Time().After(b.Time()) && c <= 0 {
+				t.Fatalf("expected %s to sort after %s", a, b)
+			}
+		}
+
+		if a.Less(b) != (c < 0) {
+			t.Fatalf("Less disagrees with Compare for %s and %s", a, b)
+		}
+		if a.Equal(b) != (c == 0) {
+			t.Fatalf("Equal disagrees with Compare for %s and %s", a, b)
+		}
+		if a.Compare(b) != c {
+			t.Fatalf("method Compare disagrees with Compare for %s and %s", a, b)
+		}
+	}
+}
+
+func TestSortFunc(t *testing.T) {
+	gen := MustNewGenerator(WithMonotonic())
+	guids := make([]GUID, 100)
+	for i := range guids {
+		g, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		guids[i] = g
+	}
+
+	shuffled := slices.Clone(guids)
+	rand.New(rand.NewSource(1)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	slices.SortFunc(shuffled, Compare)
+	if !slices.Equal(shuffled, guids) {
+		t.Fatal("expected sorted GUIDs to be in generation order")
+	}
+}
+
+func TestNil(t *testing.T) {
+	if !Nil.IsZero() {
+		t.Fatal("expected Nil to be zero")
+	}
+	if !(GUID{}).IsZero() {
+		t.Fatal("expected empty GUID to be zero")
+	}
+	if MustNew().IsZero() {
+		t.Fatal("expected generated GUID to be non-zero")
+	}
+	if !Nil.Less(MustNew()) {
+		t.Fatal("expected Nil to sort before generated GUIDs")
+	}
+}
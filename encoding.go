@@ -0,0 +1,175 @@
+package guid
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Encoding converts GUIDs to and from a textual form.
+type Encoding interface {
+	EncodeToString(g GUID) (string, error)
+	DecodeString(s string) (GUID, error)
+}
+
+var (
+	// Base36Encoding is the canonical 28-character form produced by
+	// GUID.String and accepted by ParseString.
+	Base36Encoding Encoding = base36Encoding{}
+
+	// Crockford32Encoding is a 31-character form using Crockford's base32
+	// alphabet. Decoding is case-insensitive and ambiguity-tolerant:
+	// 'I' and 'L' are read as '1', and 'O' as '0'.
+	Crockford32Encoding Encoding = newRadixEncoding("Crockford32Encoding", "0123456789ABCDEFGHJKMNPQRSTVWXYZ", map[byte]byte{
+		'I': '1', 'L': '1', 'O': '0',
+		'i': '1', 'l': '1', 'o': '0',
+	}, true, sortablePacking)
+
+	// Base62Encoding is a 26-character, case-sensitive form using the
+	// digits and the upper and lowercase letters, suited to short URLs.
+	// It packs the sortable binary form, so it accepts any prefix bytes;
+	// ShortEncoding is one character shorter but only supports lowercase
+	// base36 prefixes.
+	Base62Encoding Encoding = newRadixEncoding("Base62Encoding", base62Alphabet, nil, false, sortablePacking)
+
+	// HexEncoding is a 38-character lowercase hexadecimal form.
+	// Decoding is case-insensitive.
+	HexEncoding Encoding = newRadixEncoding("HexEncoding", "0123456789abcdef", nil, true, sortablePacking)
+)
+
+const (
+	// base62Alphabet holds the base62 digits in ascending byte order
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// sortableBits is the number of significant bits in the sortable binary form
+	sortableBits = prefixBits + tsBits + 2*intBits + randomBits
+)
+
+// packing converts GUIDs to and from a big-endian binary form whose
+// value fits in a fixed number of bits
+type packing struct {
+	bits   int
+	size   int
+	pack   func(GUID) ([]byte, error)
+	unpack func([]byte) (GUID, error)
+}
+
+// sortablePacking is the sortable binary form
+var sortablePacking = packing{
+	bits: sortableBits,
+	size: sortableSize,
+	pack: GUID.MarshalSortable,
+	unpack: func(data []byte) (GUID, error) {
+		var g GUID
+		err := g.UnmarshalSortable(data)
+		return g, err
+	},
+}
+
+// base36Encoding adapts the canonical string form to the Encoding interface
+type base36Encoding struct{}
+
+// EncodeToString returns the canonical string form of g.
+func (base36Encoding) EncodeToString(g GUID) (string, error) {
+	return g.String(), nil
+}
+
+// DecodeString parses the canonical string form of a GUID.
+func (base36Encoding) DecodeString(s string) (GUID, error) {
+	return ParseString(s)
+}
+
+// radixEncoding encodes the packed binary form of a GUID as a fixed-width
+// number in an arbitrary alphabet. Because the alphabets are in ascending
+// byte order, the packings preserve order and the width is fixed, encoded
+// GUIDs sort like Compare.
+type radixEncoding struct {
+	name     string
+	alphabet string
+	radix    *big.Int
+	values   [256]byte
+	width    int
+	packing  packing
+}
+
+// newRadixEncoding creates a radixEncoding. aliases maps additional input
+// bytes to the alphabet bytes they decode as, and foldCase makes decoding
+// case-insensitive.
+func newRadixEncoding(name, alphabet string, aliases map[byte]byte, foldCase bool, p packing) *radixEncoding {
+	e := &radixEncoding{
+		name:     name,
+		alphabet: alphabet,
+		radix:    big.NewInt(int64(len(alphabet))),
+		packing:  p,
+	}
+
+	for i := range e.values {
+		e.values[i] = invalidDigit
+	}
+	for i := 0; i < len(alphabet); i++ {
+		e.values[alphabet[i]] = byte(i)
+		if foldCase {
+			switch c := alphabet[i]; {
+			case c >= 'a' && c <= 'z':
+				e.values[c-'a'+'A'] = byte(i)
+			case c >= 'A' && c <= 'Z':
+				e.values[c-'A'+'a'] = byte(i)
+			}
+		}
+	}
+	for from, to := range aliases {
+		e.values[from] = e.values[to]
+	}
+
+	// the smallest width that can hold every packed value
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(p.bits))
+	for n := big.NewInt(1); n.Cmp(limit) < 0; n.Mul(n, e.radix) {
+		e.width++
+	}
+
+	return e
+}
+
+// EncodeToString returns the encoded form of g.
+func (e *radixEncoding) EncodeToString(g GUID) (string, error) {
+	data, err := e.packing.pack(g)
+	if err != nil {
+		return "", fmt.Errorf("guid.%s.EncodeToString: %w", e.name, err)
+	}
+
+	n := new(big.Int).SetBytes(data)
+	digit := new(big.Int)
+	out := make([]byte, e.width)
+	for i := e.width - 1; i >= 0; i-- {
+		n.QuoRem(n, e.radix, digit)
+		out[i] = e.alphabet[digit.Int64()]
+	}
+
+	return string(out), nil
+}
+
+// DecodeString parses the encoded form of a GUID.
+func (e *radixEncoding) DecodeString(s string) (GUID, error) {
+	if len(s) != e.width {
+		return GUID{}, fmt.Errorf("guid.%s.DecodeString: the string must be exactly %d characters in length: %w", e.name, e.width, ErrInvalidLength)
+	}
+
+	n := new(big.Int)
+	digit := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		d := e.values[s[i]]
+		if d == invalidDigit {
+			return GUID{}, fmt.Errorf("guid.%s.DecodeString: invalid character %q at offset %d: %w", e.name, s[i], i, ErrInvalidCharacter)
+		}
+		n.Mul(n, e.radix).Add(n, digit.SetInt64(int64(d)))
+	}
+	if n.BitLen() > e.packing.bits {
+		return GUID{}, fmt.Errorf("guid.%s.DecodeString: %w", e.name, ErrOutOfRange)
+	}
+
+	g, err := e.packing.unpack(n.FillBytes(make([]byte, e.packing.size)))
+	if err != nil {
+		return GUID{}, fmt.Errorf("guid.%s.DecodeString: %w", e.name, err)
+	}
+
+	return g, nil
+}
@@ -0,0 +1,119 @@
+package guid
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncoding(t *testing.T) {
+	encodings := []struct {
+		name  string
+		enc   Encoding
+		width int
+	}{
+		{"base36", Base36Encoding, 28},
+		{"crockford32", Crockford32Encoding, 31},
+		{"base62", Base62Encoding, 26},
+		{"hex", HexEncoding, 38},
+	}
+
+	rando := rand.New(rand.NewSource(1622222222222000000))
+	guids := []GUID{TestGUID, MustNew(), MaxForTime(MustNew().Time(), [2]byte{'z', 'z'})}
+	for i := 0; i < 500; i++ {
+		guids = append(guids, randomGUID(rando))
+	}
+
+	for _, tt := range encodings {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := ""
+			for i, g := range guids {
+				s, err := tt.enc.EncodeToString(g)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(s) != tt.width {
+					t.Fatalf("expected %d characters, got %d: %s", tt.width, len(s), s)
+				}
+				out, err := tt.enc.DecodeString(s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if out.String() != g.String() {
+					t.Fatalf("expected %s, got %s", g, out)
+				}
+
+				// encoded strings sort like Compare
+				if i > 0 {
+					if want := sign(Compare(guids[i-1], g)); sign(strings.Compare(prev, s)) != want {
+						t.Fatalf("expected Compare(%s, %s) = %d", prev, s, want)
+					}
+				}
+				prev = s
+			}
+		})
+	}
+}
+
+func TestEncodingDecodeErrors(t *testing.T) {
+	s, err := Base62Encoding.EncodeToString(TestGUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		enc     Encoding
+		in      string
+		wantErr error
+	}{
+		{"empty", Base62Encoding, "", ErrInvalidLength},
+		{"short", Base62Encoding, s[1:], ErrInvalidLength},
+		{"invalid character", Base62Encoding, s[:5] + "-" + s[6:], ErrInvalidCharacter},
+		{"too large", Base62Encoding, strings.Repeat("z", 26), ErrOutOfRange},
+		{"crockford U", Crockford32Encoding, strings.Repeat("0", 30) + "U", ErrInvalidCharacter},
+		{"hex", HexEncoding, strings.Repeat("g", 38), ErrInvalidCharacter},
+		{"base36", Base36Encoding, "short", ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.enc.DecodeString(tt.in); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := HexEncoding.EncodeToString(GUID{'i', 'd'}.SetTime(time.Unix(0, -1e6))); err == nil {
+		t.Fatal("expected an error for a GUID that is out of range")
+	}
+}
+
+func TestCrockford32Tolerance(t *testing.T) {
+	g := MustNew()
+	s, err := Crockford32Encoding.EncodeToString(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, in := range []string{
+		strings.ToLower(s),
+		strings.NewReplacer("0", "O", "1", "I").Replace(s),
+		strings.NewReplacer("0", "o", "1", "l").Replace(s),
+	} {
+		out, err := Crockford32Encoding.DecodeString(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out != g {
+			t.Fatalf("decoding %s: expected %s, got %s", in, g, out)
+		}
+	}
+
+	// base62 is case-sensitive
+	s, _ = Base62Encoding.EncodeToString(g)
+	if out, err := Base62Encoding.DecodeString(strings.ToUpper(s)); err == nil && out == g {
+		t.Fatal("expected base62 decoding to be case-sensitive")
+	}
+}
@@ -0,0 +1,96 @@
+package guid
+
+import (
+	"crypto/rand"
+	"io"
+	randv2 "math/rand/v2"
+	"runtime"
+	"sync"
+)
+
+// defaultPoolSize is the default number of bytes buffered by each shard
+// of an EntropyPool
+const defaultPoolSize = 4096
+
+// EntropyPool is a goroutine-safe buffered random source. It reads from its
+// underlying source in large chunks and hands out each buffered byte at most
+// once, which amortizes the cost of reading from crypto/rand across many
+// GUIDs. An EntropyPool may be passed to WithRandomReader.
+type EntropyPool struct {
+	src    io.Reader
+	shards []entropyShard
+}
+
+type entropyShard struct {
+	mu  sync.Mutex
+	buf []byte
+	off int
+
+	// keep shards on separate cache lines
+	_ [64]byte
+}
+
+// NewEntropyPool creates an EntropyPool that buffers size bytes per shard.
+// The pool is split into the given number of independently locked shards
+// to reduce contention; a value of zero or less uses one shard per
+// GOMAXPROCS. When more than one shard is used, src must be safe for
+// concurrent use; with a single shard, every read from src is made under
+// the shard's lock. A nil src uses crypto/rand, and a size of zero or less
+// uses a 4KiB buffer.
+func NewEntropyPool(src io.Reader, size, shards int) *EntropyPool {
+	if src == nil {
+		src = rand.Reader
+	}
+	if size <= 0 {
+		size = defaultPoolSize
+	}
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+
+	p := &EntropyPool{
+		src:    src,
+		shards: make([]entropyShard, shards),
+	}
+	for i := range p.shards {
+		p.shards[i].buf = make([]byte, size)
+		// start empty so the first read triggers a refill
+		p.shards[i].off = size
+	}
+
+	return p
+}
+
+// Read fills b with random bytes. Reads larger than a shard's buffer are
+// served directly from the underlying source.
+func (p *EntropyPool) Read(b []byte) (int, error) {
+	s := &p.shards[0]
+	if len(p.shards) > 1 {
+		s = &p.shards[randv2.IntN(len(p.shards))]
+	}
+
+	// direct reads hold the lock too, so a single shard never reads src
+	// concurrently
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(b) > len(s.buf) {
+		return io.ReadFull(p.src, b)
+	}
+
+	if len(s.buf)-s.off < len(b) {
+		// leftover bytes are discarded rather than combined with fresh ones
+		if _, err := io.ReadFull(p.src, s.buf); err != nil {
+			s.off = len(s.buf)
+			return 0, err
+		}
+		s.off = 0
+	}
+
+	n := copy(b, s.buf[s.off:])
+	// wipe the bytes that were handed out so they can never be reused
+	clear(s.buf[s.off : s.off+n])
+	s.off += n
+
+	return n, nil
+}
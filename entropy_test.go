@@ -0,0 +1,170 @@
+package guid
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// sequenceReader emits a strictly increasing sequence of uint64 values,
+// so any byte handed out twice would produce a duplicate value
+type sequenceReader struct {
+	mu sync.Mutex
+	n  uint64
+}
+
+func (s *sequenceReader) Read(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i+8 <= len(b); i += 8 {
+		s.n++
+		binary.BigEndian.PutUint64(b[i:], s.n)
+	}
+	return len(b), nil
+}
+
+// exclusiveReader fails the test if it is read from concurrently
+type exclusiveReader struct {
+	t      *testing.T
+	active atomic.Int32
+}
+
+func (r *exclusiveReader) Read(b []byte) (int, error) {
+	if r.active.Add(1) > 1 {
+		r.t.Error("concurrent read from the source")
+	}
+	defer r.active.Add(-1)
+	time.Sleep(10 * time.Microsecond)
+	clear(b)
+	return len(b), nil
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("no entropy")
+}
+
+func TestEntropyPool(t *testing.T) {
+	t.Run("no bytes are handed out twice", func(t *testing.T) {
+		const goroutines = 16
+		const perGoroutine = 1000
+
+		pool := NewEntropyPool(&sequenceReader{}, 128, 4)
+		results := make(chan uint64, goroutines*perGoroutine)
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var b [8]byte
+				for j := 0; j < perGoroutine; j++ {
+					if _, err := pool.Read(b[:]); err != nil {
+						t.Errorf("unexpected error: %v", err)
+						return
+					}
+					results <- binary.BigEndian.Uint64(b[:])
+				}
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		seen := make(map[uint64]struct{}, goroutines*perGoroutine)
+		for v := range results {
+			if _, exists := seen[v]; exists {
+				t.Fatalf("value %d handed out twice", v)
+			}
+			seen[v] = struct{}{}
+		}
+	})
+
+	t.Run("large reads bypass the buffer", func(t *testing.T) {
+		pool := NewEntropyPool(&sequenceReader{}, 16, 1)
+		b := make([]byte, 64)
+		n, err := pool.Read(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(b) {
+			t.Fatalf("expected %d bytes, got %d", len(b), n)
+		}
+	})
+
+	t.Run("a single shard serializes reads from the source", func(t *testing.T) {
+		pool := NewEntropyPool(&exclusiveReader{t: t}, 16, 1)
+		var wg sync.WaitGroup
+		for _, size := range []int{8, 64} {
+			for w := 0; w < 4; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					b := make([]byte, size)
+					for i := 0; i < 50; i++ {
+						if _, err := pool.Read(b); err != nil {
+							t.Error(err)
+							return
+						}
+					}
+				}()
+			}
+		}
+		wg.Wait()
+	})
+
+	t.Run("refill errors are returned", func(t *testing.T) {
+		pool := NewEntropyPool(errReader{}, 16, 1)
+		if _, err := pool.Read(make([]byte, 8)); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+
+	t.Run("generator", func(t *testing.T) {
+		gen := MustNewGenerator(WithRandomReader(NewEntropyPool(nil, 0, 0)))
+		seen := make(map[GUID]struct{})
+		for i := 0; i < 1000; i++ {
+			g, err := gen.Generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, exists := seen[g]; exists {
+				t.Fatalf("duplicate GUID detected: %s", g)
+			}
+			seen[g] = struct{}{}
+		}
+	})
+}
+
+func BenchmarkEntropyPool(b *testing.B) {
+	b.Run("crypto/rand", func(b *testing.B) {
+		gen := MustNewGenerator()
+		for i := 0; i < b.N; i++ {
+			_, _ = gen.Generate()
+		}
+	})
+	b.Run("pool", func(b *testing.B) {
+		gen := MustNewGenerator(WithRandomReader(NewEntropyPool(nil, 0, 0)))
+		for i := 0; i < b.N; i++ {
+			_, _ = gen.Generate()
+		}
+	})
+	b.Run("crypto/rand parallel", func(b *testing.B) {
+		gen := MustNewGenerator()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, _ = gen.Generate()
+			}
+		})
+	})
+	b.Run("pool parallel", func(b *testing.B) {
+		gen := MustNewGenerator(WithRandomReader(NewEntropyPool(nil, 0, 0)))
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, _ = gen.Generate()
+			}
+		})
+	})
+}
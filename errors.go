@@ -0,0 +1,69 @@
+package guid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidLength indicates that the input is not the expected length.
+	ErrInvalidLength = errors.New("guid: invalid length")
+
+	// ErrInvalidCharacter indicates that the input contains a byte that is
+	// not allowed in the field being parsed.
+	ErrInvalidCharacter = errors.New("guid: invalid character")
+
+	// ErrOutOfRange indicates that a field value does not fit in the field.
+	ErrOutOfRange = errors.New("guid: value out of range")
+
+	// ErrZeroGUID indicates that every field of the GUID is zero.
+	ErrZeroGUID = errors.New("guid: zero GUID")
+
+	// ErrPrefixMismatch indicates that a GUID's prefix does not match the
+	// prefix declared by the Kind of an ID.
+	ErrPrefixMismatch = errors.New("guid: prefix mismatch")
+)
+
+// Field names reported by ParseError
+const (
+	FieldPrefix      = "prefix"
+	FieldTimestamp   = "timestamp"
+	FieldFingerprint = "fingerprint"
+	FieldCounter     = "counter"
+	FieldRandom      = "random"
+)
+
+// ParseError describes why an input could not be parsed as a GUID.
+// Err is always one of ErrInvalidLength, ErrInvalidCharacter,
+// ErrOutOfRange, ErrZeroGUID or ErrPrefixMismatch, so callers can use
+// errors.Is to classify the failure and errors.As to inspect the details.
+type ParseError struct {
+	// Field is the name of the field that failed to parse. It is empty
+	// when the input as a whole was rejected, e.g. for a bad length.
+	Field string
+
+	// Offset is the byte offset of the first offending byte in the input.
+	Offset int
+
+	// Input is the offending input: the failing field's text, or the
+	// whole input when Field is empty.
+	Input string
+
+	// Err is the sentinel error describing the failure.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Field == "" {
+		if errors.Is(e.Err, ErrInvalidLength) {
+			return fmt.Sprintf("guid.Parse: the byte slice must be exactly %d bytes in length", byteSize)
+		}
+		return fmt.Sprintf("guid.Parse: invalid input '%s': %v", e.Input, e.Err)
+	}
+	return fmt.Sprintf("guid.Parse: invalid %s value '%s' at offset %d: %v", e.Field, e.Input, e.Offset, e.Err)
+}
+
+// Unwrap allows errors.Is to match the sentinel error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
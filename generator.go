@@ -2,6 +2,9 @@ package guid
 
 import (
 	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
 	"sync"
 	"sync/atomic"
@@ -9,13 +12,7 @@ import (
 )
 
 func init() {
-	globalGen.Store(Generator(&stdGenerator{
-		Random: rand.Reader,
-		Now: func() time.Time {
-			return time.Now().UTC()
-		},
-		Fingerprint: defaultFingerprint(),
-	}))
+	globalGen.Store(generatorBox{newStdGenerator()})
 }
 
 // Generator defines the contract for generating GUIDs
@@ -23,6 +20,12 @@ type Generator interface {
 	Generate() (GUID, error)
 }
 
+// BatchGenerator is an optional interface implemented by generators that
+// can fill many GUIDs at once more cheaply than repeated calls to Generate.
+type BatchGenerator interface {
+	GenerateN(dst []GUID) error
+}
+
 // stdGenerator generates GUIDs
 type stdGenerator struct {
 	Fingerprint int32
@@ -30,53 +33,428 @@ type stdGenerator struct {
 	Now         func() time.Time
 	Counter     int32
 
+	// Prefix overrides the global prefix bytes when set.
+	// The zero value defers to the global prefix.
+	Prefix [2]byte
+
+	// Monotonic guarantees that GUIDs sort by String() in the
+	// order they were generated.
+	Monotonic bool
+
+	// ClockPolicy determines how the generator reacts when the
+	// clock reports a time earlier than the last emitted timestamp.
+	ClockPolicy ClockPolicy
+
+	// OnClockRegression, when set, is called with the size of
+	// every detected clock regression.
+	OnClockRegression func(time.Duration)
+
+	// lastTime and lastCounter record the most recently emitted
+	// millisecond timestamp and counter value. In monotonic mode,
+	// lastTime may be ahead of the clock after a counter wrap.
+	lastTime    int64
+	lastCounter int32
+	started     bool
+
+	// lastClock records the most recent clock reading, in milliseconds,
+	// against which clock regressions are detected.
+	lastClock int64
+
+	// sleep is used by ClockWait; tests may replace it
+	sleep func(time.Duration)
+
 	mu sync.Mutex
 }
 
+// newStdGenerator returns a generator with the default
+// time, randomness and fingerprint providers
+func newStdGenerator() *stdGenerator {
+	return &stdGenerator{
+		Random: rand.Reader,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+		Fingerprint: defaultFingerprint(),
+	}
+}
+
+// ClockPolicy determines how a generator reacts to the wall clock moving
+// backwards, e.g. after an NTP step or a VM resume.
+type ClockPolicy int
+
+const (
+	// ClockIgnore emits GUIDs with the regressed timestamp. This is the
+	// default policy, except in monotonic mode, where it behaves like
+	// ClockReuse.
+	ClockIgnore ClockPolicy = iota
+
+	// ClockWait blocks generation until the clock catches up with the
+	// last emitted timestamp.
+	ClockWait
+
+	// ClockReuse keeps emitting the last timestamp, relying on the
+	// counter to distinguish GUIDs, until the clock catches up.
+	ClockReuse
+
+	// ClockError causes Generate to return a *ClockRegressionError until
+	// the clock catches up.
+	ClockError
+)
+
+// ErrClockRegression is matched by every *ClockRegressionError.
+var ErrClockRegression = errors.New("guid: clock regression")
+
+// ClockRegressionError is returned by generators using the ClockError
+// policy when the clock reports a time earlier than the last emitted
+// timestamp.
+type ClockRegressionError struct {
+	// Last is the most recently emitted timestamp.
+	Last time.Time
+	// Now is the time reported by the clock.
+	Now time.Time
+}
+
+// Regression returns how far the clock has moved backwards.
+func (e *ClockRegressionError) Regression() time.Duration {
+	return e.Last.Sub(e.Now)
+}
+
+func (e *ClockRegressionError) Error() string {
+	return fmt.Sprintf("guid.Generate: clock moved backwards by %s", e.Regression())
+}
+
+// Unwrap allows errors.Is to match ErrClockRegression.
+func (e *ClockRegressionError) Unwrap() error {
+	return ErrClockRegression
+}
+
+// GeneratorOption configures a Generator created by NewGenerator.
+type GeneratorOption func(*stdGenerator) error
+
+// WithClock sets the time provider used by the generator.
+func WithClock(now func() time.Time) GeneratorOption {
+	return func(g *stdGenerator) error {
+		if now == nil {
+			return fmt.Errorf("guid.WithClock: clock must not be nil")
+		}
+		g.Now = now
+		return nil
+	}
+}
+
+// WithRandomReader sets the source of randomness used by the generator.
+// The reader must be safe for concurrent use if the generator is shared
+// between goroutines.
+func WithRandomReader(r io.Reader) GeneratorOption {
+	return func(g *stdGenerator) error {
+		if r == nil {
+			return fmt.Errorf("guid.WithRandomReader: reader must not be nil")
+		}
+		g.Random = r
+		return nil
+	}
+}
+
+// WithGeneratorFingerprint sets the device fingerprint embedded in every
+// GUID produced by the generator. Out of band values are folded into
+// the valid range.
+func WithGeneratorFingerprint(fp int32) GeneratorOption {
+	return func(g *stdGenerator) error {
+		g.Fingerprint = int32(filter(fp))
+		return nil
+	}
+}
+
+// WithCounterStart sets the initial value of the generator's monotonic
+// counter. The value must be in the range [0, 36^4).
+func WithCounterStart(c int32) GeneratorOption {
+	return func(g *stdGenerator) error {
+		if c < 0 || c >= maxInt {
+			return fmt.Errorf("guid.WithCounterStart: counter must be in the range [0, %d)", maxInt)
+		}
+		g.Counter = c
+		return nil
+	}
+}
+
+// WithGeneratorPrefix sets the prefix bytes used by the generator in place
+// of the global prefix. Prefix bytes must be lowercase base36 characters.
+func WithGeneratorPrefix(b1, b2 byte) GeneratorOption {
+	return func(g *stdGenerator) error {
+		if !(isValidPrefixByte(b1) && isValidPrefixByte(b2)) {
+			return fmt.Errorf("guid.WithGeneratorPrefix: prefix bytes must be base36-compatible and lowercase")
+		}
+		g.Prefix = [2]byte{b1, b2}
+		return nil
+	}
+}
+
+// WithMonotonic enables monotonic mode. A monotonic generator never emits
+// a timestamp earlier than the previous one, and GUIDs created within the
+// same millisecond are ordered by their counter, so the lexical order of
+// their strings always matches the order in which they were generated.
+// When the counter wraps within a single millisecond, the timestamp is
+// advanced by one millisecond to preserve ordering.
+func WithMonotonic() GeneratorOption {
+	return func(g *stdGenerator) error {
+		g.Monotonic = true
+		return nil
+	}
+}
+
+// WithClockPolicy sets how the generator reacts to the clock moving backwards.
+func WithClockPolicy(p ClockPolicy) GeneratorOption {
+	return func(g *stdGenerator) error {
+		if p < ClockIgnore || p > ClockError {
+			return fmt.Errorf("guid.WithClockPolicy: unknown clock policy %d", p)
+		}
+		g.ClockPolicy = p
+		return nil
+	}
+}
+
+// WithClockRegressionHook registers a callback that receives the size of
+// every clock regression detected by the generator. The callback is
+// invoked synchronously from Generate, outside of the generator's lock.
+func WithClockRegressionHook(fn func(time.Duration)) GeneratorOption {
+	return func(g *stdGenerator) error {
+		g.OnClockRegression = fn
+		return nil
+	}
+}
+
+// NewGenerator creates a standalone Generator that is independent of the
+// global generator. Options that are not supplied fall back to the same
+// defaults used by the global generator.
+func NewGenerator(opts ...GeneratorOption) (Generator, error) {
+	g := newStdGenerator()
+	for i := range opts {
+		if err := opts[i](g); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// MustNewGenerator calls NewGenerator and panics on error.
+func MustNewGenerator(opts ...GeneratorOption) Generator {
+	g, err := NewGenerator(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
 var (
 	// globalGenerator is stored in an atomic.Value for safe concurrent access.
+	// Generators are boxed because an atomic.Value only accepts values of
+	// a single concrete type.
 	// nolint: gochecknoglobals
-	globalGen atomic.Value
+	globalGen atomic.Value // stores generatorBox
 
 	setOnce sync.Once
 )
 
+// generatorBox wraps the global generator
+type generatorBox struct {
+	Generator
+}
+
 // SetGlobalGenerator allows for the manual assignment of the GUID generator.
 // The main usefulness of this function is primarily for testing, but
 // this function can also be used to inject custom time and randomness
 // providers.
 // Note that this function can be called only once per runtime.
-// Subsequent calls are no-ops.
+// Subsequent calls, and calls made after LockGlobals, are no-ops.
+// It panics if g is nil.
+// Tests that need to replace the generator repeatedly should use
+// the guidtest package instead.
 func SetGlobalGenerator(g Generator) {
+	if g == nil {
+		panic("guid.SetGlobalGenerator: generator must not be nil")
+	}
+
+	globalsMu.Lock()
+	defer globalsMu.Unlock()
+	if globalsLocked {
+		return
+	}
 	setOnce.Do(func() {
-		globalGen.Store(g)
+		globalGen.Store(generatorBox{g})
 	})
 }
 
-func (g *stdGenerator) randomInt64() (int64, error) {
-	return randomInt64(g.Random)
+// prefix returns the generator's prefix bytes, falling back to the global prefix
+func (g *stdGenerator) prefix() [2]byte {
+	if g.Prefix != [2]byte{} {
+		return g.Prefix
+	}
+	return globalPrefix.Load().([2]byte)
+}
+
+// policy returns the effective clock policy
+func (g *stdGenerator) policy() ClockPolicy {
+	if g.Monotonic && g.ClockPolicy == ClockIgnore {
+		return ClockReuse
+	}
+	return g.ClockPolicy
+}
+
+// tick reads the clock in milliseconds, applying the clock policy when the
+// clock has moved backwards past its previous reading. Regressions are
+// detected against the clock rather than the last emitted timestamp, so a
+// timestamp borrowed by monotonic mode is not mistaken for one. The caller
+// must hold g.mu.
+func (g *stdGenerator) tick() (ms int64, regression time.Duration, err error) {
+	ms = g.Now().UnixNano() / 1e6
+
+	if g.started && ms < g.lastClock {
+		regression = time.Duration(g.lastClock-ms) * time.Millisecond
+		switch g.policy() {
+		case ClockWait:
+			sleep := g.sleep
+			if sleep == nil {
+				sleep = time.Sleep
+			}
+			for ms < g.lastClock {
+				sleep(time.Duration(g.lastClock-ms) * time.Millisecond)
+				ms = g.Now().UnixNano() / 1e6
+			}
+		case ClockReuse:
+			ms = g.lastClock
+		case ClockError:
+			return 0, regression, &ClockRegressionError{
+				Last: time.Unix(0, g.lastClock*1e6),
+				Now:  time.Unix(0, ms*1e6),
+			}
+		}
+	}
+	g.lastClock = ms
+
+	return ms, regression, nil
+}
+
+// reserve reserves n consecutive counter values and the timestamp (in
+// milliseconds) for the first of them, applying the clock policy when the
+// clock has moved backwards. The caller must hold g.mu.
+func (g *stdGenerator) reserve(n int) (ms int64, counter int32, regression time.Duration, err error) {
+	ms, regression, err = g.tick()
+	if err != nil {
+		return 0, 0, regression, err
+	}
+
+	counter = g.Counter
+
+	if g.Monotonic && g.started {
+		// the last timestamp may have been borrowed ahead of the clock
+		if ms < g.lastTime {
+			ms = g.lastTime
+		}
+		// a wrapped counter would sort before its predecessor
+		// within the same millisecond, so borrow the next one
+		if ms == g.lastTime && counter <= g.lastCounter {
+			ms++
+		}
+	}
+
+	end := int64(counter) + int64(n) - 1
+	last := ms
+	if g.Monotonic {
+		last += end / maxInt
+	}
+	g.Counter = int32((end + 1) % maxInt)
+	g.lastTime, g.lastCounter, g.started = last, int32(end%maxInt), true
+
+	return ms, counter, regression, nil
+}
+
+// build assembles a GUID from its generated components
+func (g *stdGenerator) build(ms int64, counter, fingerprint int32, r int64) GUID {
+	v := (GUID{}).SetTime(time.Unix(0, ms*1e6)).SetCounter(counter).SetFingerprint(fingerprint).SetRandom(r)
+	// set prefix bytes
+	pfx := g.prefix()
+	v[0] = pfx[0]
+	v[1] = pfx[1]
+
+	return v
 }
 
 // Generate will create a new GUID.
 func (g *stdGenerator) Generate() (GUID, error) {
+	return g.generateRequest(&request{})
+}
+
+// generateRequest creates a new GUID, honoring the overrides in req.
+func (g *stdGenerator) generateRequest(req *request) (GUID, error) {
+	var ms int64
+	var counter int32
+
 	g.mu.Lock()
-	counter := g.Counter
-	g.Counter++
-	if g.Counter >= maxInt {
-		g.Counter = 0
+	if req.hasTime {
+		// backfilled GUIDs only consume a counter value
+		counter = g.Counter
+		g.Counter++
+		if g.Counter >= maxInt {
+			g.Counter = 0
+		}
+		g.mu.Unlock()
+		ms = req.time.UnixNano() / 1e6
+	} else {
+		var regression time.Duration
+		var err error
+		ms, counter, regression, err = g.reserve(1)
+		g.mu.Unlock()
+
+		if regression > 0 && g.OnClockRegression != nil {
+			g.OnClockRegression(regression)
+		}
+		if err != nil {
+			return GUID{}, err
+		}
 	}
-	g.mu.Unlock()
 
-	r, err := g.randomInt64()
+	r, err := randomInt64(req.randomOr(g.Random))
 	if err != nil {
 		return GUID{}, err
 	}
 
-	v := (GUID{}).SetTime(g.Now()).SetCounter(counter).SetFingerprint(g.Fingerprint).SetRandom(r)
-	// set prefix bytes
-	pfx := globalPrefix.Load().([2]byte)
-	v[0] = pfx[0]
-	v[1] = pfx[1]
+	return g.build(ms, counter, req.fingerprintOr(g.Fingerprint), r), nil
+}
+
+// GenerateN fills dst with new GUIDs. The counter range for the whole
+// batch is reserved under a single lock acquisition, and the random
+// components are read from the random source in one call.
+// In monotonic mode, the GUIDs in dst are in ascending order.
+func (g *stdGenerator) GenerateN(dst []GUID) error {
+	if len(dst) == 0 {
+		return nil
+	}
+
+	g.mu.Lock()
+	ms, counter, regression, err := g.reserve(len(dst))
+	g.mu.Unlock()
+
+	if regression > 0 && g.OnClockRegression != nil {
+		g.OnClockRegression(regression)
+	}
+	if err != nil {
+		return err
+	}
+
+	rnd := make([]byte, 8*len(dst))
+	if _, err := io.ReadFull(g.Random, rnd); err != nil {
+		return err
+	}
+
+	for i := range dst {
+		c := int64(counter) + int64(i)
+		t := ms
+		if g.Monotonic {
+			t += c / maxInt
+		}
+		r := int64(binary.BigEndian.Uint64(rnd[i*8:]) % uint64(maxRandom))
+		dst[i] = g.build(t, int32(c%maxInt), g.Fingerprint, r)
+	}
 
-	return v, nil
+	return nil
 }
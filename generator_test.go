@@ -1,6 +1,7 @@
 package guid
 
 import (
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -96,6 +97,28 @@ func TestConcurrentGeneration(t *testing.T) {
 	}
 }
 
+func BenchmarkConcurrentGeneration(b *testing.B) {
+	b.Run("std", func(b *testing.B) {
+		gen := MustNewGenerator()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, _ = gen.Generate()
+			}
+		})
+	})
+	b.Run("sharded", func(b *testing.B) {
+		gen, err := NewShardedGenerator()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, _ = gen.Generate()
+			}
+		})
+	})
+}
+
 func TestCounterWraparound(t *testing.T) {
 	gen := &stdGenerator{
 		Fingerprint: 42,
@@ -141,3 +164,425 @@ func BenchmarkNewWithOptions(b *testing.B) {
 
 	}
 }
+
+func TestNewGenerator(t *testing.T) {
+	ts := int64(1600000000000000000)
+
+	t.Run("options", func(t *testing.T) {
+		gen, err := NewGenerator(
+			WithClock(func() time.Time { return time.Unix(0, ts) }),
+			WithRandomReader(newTestReader([8]byte{1, 2, 3, 4, 5, 6, 7, 8})),
+			WithGeneratorFingerprint(4242),
+			WithCounterStart(99),
+			WithGeneratorPrefix('u', 's'),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		g, err := gen.Generate()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if b1, b2 := g.PrefixBytes(); b1 != 'u' || b2 != 's' {
+			t.Fatalf("expected prefix 'us', got '%c%c'", b1, b2)
+		}
+		if g.Time().UnixNano() != ts {
+			t.Fatalf("expected time %d, got %d", ts, g.Time().UnixNano())
+		}
+		if g.Fingerprint() != 4242 {
+			t.Fatalf("expected fingerprint 4242, got %d", g.Fingerprint())
+		}
+		if g.Counter() != 99 {
+			t.Fatalf("expected counter 99, got %d", g.Counter())
+		}
+
+		g, err = gen.Generate()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if g.Counter() != 100 {
+			t.Fatalf("expected counter 100, got %d", g.Counter())
+		}
+	})
+
+	t.Run("independent counters", func(t *testing.T) {
+		gen1 := MustNewGenerator(WithCounterStart(10))
+		gen2 := MustNewGenerator(WithCounterStart(10))
+		for i := 0; i < 3; i++ {
+			if _, err := gen1.Generate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		g, err := gen2.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Counter() != 10 {
+			t.Fatalf("expected counter 10, got %d", g.Counter())
+		}
+	})
+
+	t.Run("defaults to global prefix", func(t *testing.T) {
+		g, err := MustNewGenerator().Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pfx := globalPrefix.Load().([2]byte)
+		if b1, b2 := g.PrefixBytes(); b1 != pfx[0] || b2 != pfx[1] {
+			t.Fatalf("expected prefix '%c%c', got '%c%c'", pfx[0], pfx[1], b1, b2)
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		tests := []struct {
+			name string
+			opt  GeneratorOption
+		}{
+			{name: "nil clock", opt: WithClock(nil)},
+			{name: "nil reader", opt: WithRandomReader(nil)},
+			{name: "negative counter", opt: WithCounterStart(-1)},
+			{name: "counter too large", opt: WithCounterStart(maxInt)},
+			{name: "uppercase prefix", opt: WithGeneratorPrefix('U', 's')},
+		}
+		for i := range tests {
+			tt := tests[i]
+			t.Run(tt.name, func(t *testing.T) {
+				if _, err := NewGenerator(tt.opt); err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
+func TestMonotonicGenerator(t *testing.T) {
+	ts := int64(1600000000000000000)
+
+	t.Run("clock steps backwards", func(t *testing.T) {
+		// the clock jumps around, including backwards
+		steps := []int64{0, 0, 5, 3, 3, -10, 7, 7, 2}
+		idx := 0
+		gen := MustNewGenerator(
+			WithMonotonic(),
+			WithClock(func() time.Time {
+				v := time.Unix(0, ts+steps[idx]*1e6)
+				idx++
+				return v
+			}),
+		)
+
+		var prev GUID
+		for i := range steps {
+			g, err := gen.Generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if i > 0 {
+				if g.String() <= prev.String() {
+					t.Fatalf("expected %s to sort after %s", g, prev)
+				}
+				if g.Time().Before(prev.Time()) {
+					t.Fatalf("timestamp went backwards: %v before %v", g.Time(), prev.Time())
+				}
+			}
+			prev = g
+		}
+	})
+
+	t.Run("counter wraps within a millisecond", func(t *testing.T) {
+		gen := MustNewGenerator(
+			WithMonotonic(),
+			WithCounterStart(maxInt-2),
+			WithClock(func() time.Time { return time.Unix(0, ts) }),
+		)
+
+		var out []GUID
+		for i := 0; i < 4; i++ {
+			g, err := gen.Generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			out = append(out, g)
+		}
+		for i := 1; i < len(out); i++ {
+			if out[i].String() <= out[i-1].String() {
+				t.Fatalf("expected %s to sort after %s", out[i], out[i-1])
+			}
+		}
+		if out[2].Counter() != 0 {
+			t.Fatalf("expected counter to wrap to 0, got %d", out[2].Counter())
+		}
+		if out[2].Time().UnixNano() != ts+1e6 {
+			t.Fatalf("expected timestamp to advance by 1ms after wrap, got %v", out[2].Time())
+		}
+	})
+}
+
+func TestClockRegression(t *testing.T) {
+	ts := int64(1600000000000000000)
+
+	// newClock returns a clock that can be moved by the test
+	newClock := func() (func() time.Time, *int64) {
+		offset := new(int64)
+		return func() time.Time {
+			return time.Unix(0, ts+*offset*1e6)
+		}, offset
+	}
+
+	t.Run("ignore", func(t *testing.T) {
+		clock, offset := newClock()
+		var reported []time.Duration
+		gen := MustNewGenerator(
+			WithClock(clock),
+			WithClockRegressionHook(func(d time.Duration) { reported = append(reported, d) }),
+		)
+		*offset = 10
+		if _, err := gen.Generate(); err != nil {
+			t.Fatal(err)
+		}
+		*offset = 4
+		g, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Time().UnixNano() != ts+4e6 {
+			t.Fatalf("expected regressed timestamp, got %v", g.Time())
+		}
+		if len(reported) != 1 || reported[0] != 6*time.Millisecond {
+			t.Fatalf("expected a single 6ms regression, got %v", reported)
+		}
+	})
+
+	t.Run("reuse", func(t *testing.T) {
+		clock, offset := newClock()
+		gen := MustNewGenerator(WithClock(clock), WithClockPolicy(ClockReuse))
+		*offset = 10
+		g1, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		*offset = 4
+		g2, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g2.Time().Equal(g1.Time()) {
+			t.Fatalf("expected timestamp %v to be reused, got %v", g1.Time(), g2.Time())
+		}
+		if g2.Counter() != g1.Counter()+1 {
+			t.Fatalf("expected counter %d, got %d", g1.Counter()+1, g2.Counter())
+		}
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		clock, offset := newClock()
+		gen := MustNewGenerator(WithClock(clock), WithClockPolicy(ClockWait)).(*stdGenerator)
+		var slept time.Duration
+		gen.sleep = func(d time.Duration) {
+			slept += d
+			*offset += int64(d / time.Millisecond)
+		}
+		*offset = 10
+		if _, err := gen.Generate(); err != nil {
+			t.Fatal(err)
+		}
+		*offset = 7
+		g, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slept != 3*time.Millisecond {
+			t.Fatalf("expected to sleep 3ms, slept %s", slept)
+		}
+		if g.Time().UnixNano() != ts+10e6 {
+			t.Fatalf("expected timestamp after waiting, got %v", g.Time())
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		clock, offset := newClock()
+		gen := MustNewGenerator(WithClock(clock), WithClockPolicy(ClockError))
+		*offset = 10
+		g1, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		*offset = 8
+		_, err = gen.Generate()
+		if !errors.Is(err, ErrClockRegression) {
+			t.Fatalf("expected ErrClockRegression, got %v", err)
+		}
+		var cre *ClockRegressionError
+		if !errors.As(err, &cre) {
+			t.Fatalf("expected *ClockRegressionError, got %T", err)
+		}
+		if cre.Regression() != 2*time.Millisecond {
+			t.Fatalf("expected 2ms regression, got %s", cre.Regression())
+		}
+
+		// the counter is not consumed by a failed generation
+		*offset = 10
+		g2, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g2.Counter() != g1.Counter()+1 {
+			t.Fatalf("expected counter %d, got %d", g1.Counter()+1, g2.Counter())
+		}
+	})
+
+	t.Run("counter wrap is not a regression", func(t *testing.T) {
+		for _, policy := range []ClockPolicy{ClockError, ClockWait} {
+			clock, _ := newClock()
+			var reported []time.Duration
+			gen := MustNewGenerator(
+				WithMonotonic(),
+				WithClock(clock),
+				WithClockPolicy(policy),
+				WithCounterStart(maxInt-1),
+				WithClockRegressionHook(func(d time.Duration) { reported = append(reported, d) }),
+			).(*stdGenerator)
+			gen.sleep = func(d time.Duration) {
+				t.Fatalf("policy %d: unexpected sleep of %s", policy, d)
+			}
+
+			// the second GUID wraps the counter and borrows the next
+			// millisecond, which the clock has not reached yet
+			var prev GUID
+			for i := 0; i < 4; i++ {
+				g, err := gen.Generate()
+				if err != nil {
+					t.Fatalf("policy %d: GUID %d: %v", policy, i, err)
+				}
+				if i > 0 && g.String() <= prev.String() {
+					t.Fatalf("policy %d: expected %s to sort after %s", policy, g, prev)
+				}
+				prev = g
+			}
+			if prev.Time().UnixNano() != ts+1e6 || prev.Counter() != 2 {
+				t.Fatalf("policy %d: expected counter 2 at %v, got %d at %v", policy, time.Unix(0, ts+1e6), prev.Counter(), prev.Time())
+			}
+			if len(reported) != 0 {
+				t.Fatalf("policy %d: expected no regressions, got %v", policy, reported)
+			}
+		}
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		if _, err := NewGenerator(WithClockPolicy(ClockPolicy(42))); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func TestGenerateN(t *testing.T) {
+	ts := int64(1600000000000000000)
+
+	t.Run("unique", func(t *testing.T) {
+		gen := MustNewGenerator().(BatchGenerator)
+		out := make([]GUID, 1000)
+		if err := gen.GenerateN(out); err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[GUID]struct{}, len(out))
+		for i := range out {
+			if _, exists := seen[out[i]]; exists {
+				t.Fatalf("duplicate GUID detected: %s", out[i])
+			}
+			seen[out[i]] = struct{}{}
+		}
+	})
+
+	t.Run("monotonic across counter wrap", func(t *testing.T) {
+		gen := MustNewGenerator(
+			WithMonotonic(),
+			WithCounterStart(maxInt-5),
+			WithClock(func() time.Time { return time.Unix(0, ts) }),
+		)
+		first, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := make([]GUID, 10)
+		if err := gen.(BatchGenerator).GenerateN(out); err != nil {
+			t.Fatal(err)
+		}
+		prev := first
+		for i := range out {
+			if out[i].String() <= prev.String() {
+				t.Fatalf("expected %s to sort after %s", out[i], prev)
+			}
+			prev = out[i]
+		}
+		if out[9].Counter() != 5 {
+			t.Fatalf("expected counter 5, got %d", out[9].Counter())
+		}
+
+		// the generator picks up where the batch left off
+		next, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.String() <= out[9].String() {
+			t.Fatalf("expected %s to sort after %s", next, out[9])
+		}
+		if next.Counter() != 6 {
+			t.Fatalf("expected counter 6, got %d", next.Counter())
+		}
+	})
+
+	t.Run("batch larger than the counter space", func(t *testing.T) {
+		gen := MustNewGenerator(
+			WithMonotonic(),
+			WithClockPolicy(ClockError),
+			WithClock(func() time.Time { return time.Unix(0, ts) }),
+		)
+		out := make([]GUID, 2*maxInt+10)
+		if err := gen.(BatchGenerator).GenerateN(out); err != nil {
+			t.Fatal(err)
+		}
+		last := out[len(out)-1]
+		if last.Time().UnixNano() != ts+2e6 {
+			t.Fatalf("expected the batch to end at %v, got %v", time.Unix(0, ts+2e6), last.Time())
+		}
+
+		// the batch ran ahead of the clock, which is not a regression
+		next, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.String() <= last.String() {
+			t.Fatalf("expected %s to sort after %s", next, last)
+		}
+	})
+
+	t.Run("NewBatch", func(t *testing.T) {
+		out, err := NewBatch(50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out) != 50 {
+			t.Fatalf("expected 50 GUIDs, got %d", len(out))
+		}
+		if _, err := NewBatch(-1); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func BenchmarkNewBatch(b *testing.B) {
+	const n = 1000
+	b.Run("New", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < n; j++ {
+				_, _ = New()
+			}
+		}
+	})
+	b.Run("NewBatch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = NewBatch(n)
+		}
+	})
+}
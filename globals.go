@@ -0,0 +1,84 @@
+package guid
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrGlobalsLocked is returned when the global configuration is
+// changed after LockGlobals has been called.
+var ErrGlobalsLocked = errors.New("guid: global configuration is locked")
+
+var (
+	// globalsMu serializes swaps of the global generator and prefix
+	// with LockGlobals
+	globalsMu     sync.Mutex
+	globalsLocked bool
+)
+
+// LockGlobals permanently freezes the global generator and prefix bytes.
+// Once locked, SetGlobalGenerator is a no-op, and SetGlobalPrefixBytes and
+// the Swap functions return ErrGlobalsLocked. Applications that want to
+// guarantee their configuration cannot be changed, including by test
+// helpers, should call LockGlobals after configuring the package at startup.
+func LockGlobals() {
+	globalsMu.Lock()
+	globalsLocked = true
+	globalsMu.Unlock()
+}
+
+// GlobalsLocked reports whether LockGlobals has been called.
+func GlobalsLocked() bool {
+	globalsMu.Lock()
+	defer globalsMu.Unlock()
+	return globalsLocked
+}
+
+// GlobalGenerator returns the current global generator.
+func GlobalGenerator() Generator {
+	return globalGen.Load().(generatorBox).Generator
+}
+
+// GlobalPrefixBytes returns the current global prefix bytes.
+func GlobalPrefixBytes() (byte, byte) {
+	pfx := globalPrefix.Load().([2]byte)
+	return pfx[0], pfx[1]
+}
+
+// SwapGlobalGenerator replaces the global generator and returns the
+// previous one. Unlike SetGlobalGenerator, it can be called any number of
+// times, which makes it suitable for tests that need to restore the
+// previous generator; see the guidtest package. It fails with
+// ErrGlobalsLocked once LockGlobals has been called.
+func SwapGlobalGenerator(g Generator) (Generator, error) {
+	if g == nil {
+		return nil, fmt.Errorf("guid.SwapGlobalGenerator: generator must not be nil")
+	}
+
+	globalsMu.Lock()
+	defer globalsMu.Unlock()
+
+	if globalsLocked {
+		return nil, fmt.Errorf("guid.SwapGlobalGenerator: %w", ErrGlobalsLocked)
+	}
+	return globalGen.Swap(generatorBox{g}).(generatorBox).Generator, nil
+}
+
+// SwapGlobalPrefixBytes replaces the global prefix bytes and returns the
+// previous ones. Unlike SetGlobalPrefixBytes, it can be called any number
+// of times; see the guidtest package. It fails with ErrGlobalsLocked once
+// LockGlobals has been called.
+func SwapGlobalPrefixBytes(b1, b2 byte) ([2]byte, error) {
+	if !(isValidPrefixByte(b1) && isValidPrefixByte(b2)) {
+		return [2]byte{}, fmt.Errorf("guid.SwapGlobalPrefixBytes: prefix bytes must be base36-compatible and lowercase")
+	}
+
+	globalsMu.Lock()
+	defer globalsMu.Unlock()
+
+	if globalsLocked {
+		return [2]byte{}, fmt.Errorf("guid.SwapGlobalPrefixBytes: %w", ErrGlobalsLocked)
+	}
+	return globalPrefix.Swap([2]byte{b1, b2}).([2]byte), nil
+}
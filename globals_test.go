@@ -0,0 +1,108 @@
+package guid
+
+import (
+	"errors"
+	"testing"
+)
+
+// unlockGlobals reverts LockGlobals for the duration of a test
+func unlockGlobals(t *testing.T) {
+	t.Cleanup(func() {
+		globalsMu.Lock()
+		globalsLocked = false
+		globalsMu.Unlock()
+	})
+}
+
+func TestSwapGlobals(t *testing.T) {
+	t.Run("generator", func(t *testing.T) {
+		gen := MustNewGenerator(WithCounterStart(1234))
+		prev, err := SwapGlobalGenerator(gen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _, _ = SwapGlobalGenerator(prev) }()
+
+		if GlobalGenerator() != gen {
+			t.Fatal("expected swapped generator to be the global generator")
+		}
+		if g := MustNew(); g.Counter() != 1234 {
+			t.Fatalf("expected counter 1234, got %d", g.Counter())
+		}
+
+		// generators of any concrete type can be swapped in
+		if _, err := SwapGlobalGenerator(fixedGenerator{TestGUID}); err != nil {
+			t.Fatal(err)
+		}
+		if g := MustNew(); g != TestGUID {
+			t.Fatalf("expected %s, got %s", TestGUID, g)
+		}
+
+		if _, err := SwapGlobalGenerator(nil); err == nil {
+			t.Fatal("expected error for nil generator")
+		}
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		prev, err := SwapGlobalPrefixBytes('s', 'w')
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _, _ = SwapGlobalPrefixBytes(prev[0], prev[1]) }()
+
+		if b1, b2 := GlobalPrefixBytes(); b1 != 's' || b2 != 'w' {
+			t.Fatalf("expected global prefix 'sw', got '%c%c'", b1, b2)
+		}
+		if b1, b2 := MustNew().PrefixBytes(); b1 != 's' || b2 != 'w' {
+			t.Fatalf("expected prefix 'sw', got '%c%c'", b1, b2)
+		}
+
+		if _, err := SwapGlobalPrefixBytes('S', 'W'); err == nil {
+			t.Fatal("expected error for invalid prefix bytes")
+		}
+	})
+}
+
+func TestLockGlobals(t *testing.T) {
+	unlockGlobals(t)
+
+	b1, b2 := GlobalPrefixBytes()
+	gen := GlobalGenerator()
+
+	LockGlobals()
+	if !GlobalsLocked() {
+		t.Fatal("expected globals to be locked")
+	}
+
+	if _, err := SwapGlobalGenerator(MustNewGenerator()); !errors.Is(err, ErrGlobalsLocked) {
+		t.Fatalf("expected ErrGlobalsLocked, got %v", err)
+	}
+	if _, err := SwapGlobalPrefixBytes('l', 'k'); !errors.Is(err, ErrGlobalsLocked) {
+		t.Fatalf("expected ErrGlobalsLocked, got %v", err)
+	}
+
+	// the one-shot setters do nothing, and the prefix setter says so
+	SetGlobalGenerator(MustNewGenerator())
+	if err := SetGlobalPrefixBytes('l', 'k'); !errors.Is(err, ErrGlobalsLocked) {
+		t.Fatalf("expected ErrGlobalsLocked, got %v", err)
+	}
+	if GlobalGenerator() != gen {
+		t.Fatal("expected global generator to be unchanged")
+	}
+	if c1, c2 := GlobalPrefixBytes(); c1 != b1 || c2 != b2 {
+		t.Fatalf("expected global prefix '%c%c', got '%c%c'", b1, b2, c1, c2)
+	}
+}
+
+func TestSetGlobalGeneratorNil(t *testing.T) {
+	gen := GlobalGenerator()
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected SetGlobalGenerator(nil) to panic")
+		}
+		if GlobalGenerator() != gen {
+			t.Fatal("expected global generator to be unchanged")
+		}
+	}()
+	SetGlobalGenerator(nil)
+}
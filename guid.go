@@ -4,8 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/binary"
 	"fmt"
-	"strconv"
-	"strings"
+	"io"
 	"time"
 )
 
@@ -33,6 +32,13 @@ const (
 	// the binary data to generate a string.
 	base = 36
 
+	// binarySize is the size of the MarshalBinary form, in bytes
+	binarySize = 22
+
+	// binaryVersion identifies the layout of the MarshalBinary form
+	binaryVersion = 1
+
+	maxTime   = 2821109907456     // 36^8
 	maxInt    = 1679616           // 36^4 or base^fieldSize
 	maxRandom = 3656158440062976 // 36^10
 )
@@ -44,33 +50,146 @@ const (
 // [[b, b], [b, b, b, b, b, b, b, b], [b, b, b, b], [b, b, b, b], [b, b, b, b, b, b, b, b, b, b]]
 type GUID [byteSize]byte
 
-// Option is a function that allows for mutating a GUID
-type Option func(GUID) GUID
+// request describes a single GUID generation. The zero value
+// generates a GUID using the global generator.
+type request struct {
+	gen Generator
+
+	time    time.Time
+	hasTime bool
+
+	fingerprint    int32
+	hasFingerprint bool
+
+	random io.Reader
+
+	prefix    [2]byte
+	hasPrefix bool
+}
+
+// fingerprintOr returns the requested fingerprint, or def if none was requested
+func (r *request) fingerprintOr(def int32) int32 {
+	if r.hasFingerprint {
+		return r.fingerprint
+	}
+	return def
+}
+
+// randomOr returns the requested random source, or def if none was requested
+func (r *request) randomOr(def io.Reader) io.Reader {
+	if r.random != nil {
+		return r.random
+	}
+	return def
+}
+
+// requestGenerator is implemented by generators that can honor
+// per-call options while generating
+type requestGenerator interface {
+	generateRequest(req *request) (GUID, error)
+}
+
+// Option configures the generation of a single GUID.
+type Option func(*request)
 
 // WithPrefixBytes sets the prefix bytes for a single GUID. To set GUID prefix
 // bytes globally, use the SetGlobalPrefixBytes function.
 func WithPrefixBytes(b1, b2 byte) Option {
-	return func(g GUID) GUID {
-		g[0] = b1
-		g[1] = b2
-		return g
+	return func(r *request) {
+		r.prefix = [2]byte{b1, b2}
+		r.hasPrefix = true
+	}
+}
+
+// WithTime generates the GUID with the given timestamp instead of reading
+// the generator's clock, e.g. when backfilling historical records. The
+// timestamp does not take part in monotonic ordering or clock regression
+// detection.
+func WithTime(t time.Time) Option {
+	return func(r *request) {
+		r.time = t
+		r.hasTime = true
+	}
+}
+
+// WithFingerprint generates the GUID with the given fingerprint instead of
+// the generator's fingerprint.
+func WithFingerprint(fp int32) Option {
+	return func(r *request) {
+		r.fingerprint = fp
+		r.hasFingerprint = true
+	}
+}
+
+// WithRandomSource reads the random component of the GUID from rd instead
+// of the generator's random source.
+func WithRandomSource(rd io.Reader) Option {
+	return func(r *request) {
+		r.random = rd
+	}
+}
+
+// WithGenerator generates the GUID with g instead of the global generator.
+func WithGenerator(g Generator) Option {
+	return func(r *request) {
+		r.gen = g
 	}
 }
 
 // New creates a GUID using the global generator or returns an error.
 func New(opts ...Option) (GUID, error) {
-	out, err := globalGen.Load().(Generator).Generate()
+	var req request
+	for i := range opts {
+		opts[i](&req)
+	}
+
+	gen := req.gen
+	if gen == nil {
+		gen = GlobalGenerator()
+	}
+
+	var out GUID
+	var err error
+	if rg, ok := gen.(requestGenerator); ok {
+		out, err = rg.generateRequest(&req)
+	} else {
+		// generators outside of this package know nothing about
+		// requests, so overrides are applied after the fact
+		out, err = gen.Generate()
+		if err == nil {
+			out, err = req.apply(out)
+		}
+	}
 	if err != nil {
 		return GUID{}, err
 	}
 
-	for i := range opts {
-		out = opts[i](out)
+	if req.hasPrefix {
+		out[0] = req.prefix[0]
+		out[1] = req.prefix[1]
 	}
 
 	return out, nil
 }
 
+// apply overrides the fields of an already generated GUID
+func (r *request) apply(g GUID) (GUID, error) {
+	if r.hasTime {
+		g = g.SetTime(r.time)
+	}
+	if r.hasFingerprint {
+		g = g.SetFingerprint(r.fingerprint)
+	}
+	if r.random != nil {
+		v, err := randomInt64(r.random)
+		if err != nil {
+			return GUID{}, err
+		}
+		g = g.SetRandom(v)
+	}
+	return g, nil
+}
+
 // MustNew creates a GUID using the global generator or panics on error.
 func MustNew(opts ...Option) GUID {
 	g, err := New(opts...)
@@ -80,12 +199,84 @@ func MustNew(opts ...Option) GUID {
 	return g
 }
 
+// NewBatch creates n GUIDs using the global generator. If the global
+// generator implements BatchGenerator, the GUIDs are generated in a
+// single batch; otherwise Generate is called n times.
+func NewBatch(n int) ([]GUID, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("guid.NewBatch: n must not be negative")
+	}
+	out := make([]GUID, n)
+	gen := GlobalGenerator()
+	if bg, ok := gen.(BatchGenerator); ok {
+		if err := bg.GenerateN(out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	for i := range out {
+		g, err := gen.Generate()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = g
+	}
+
+	return out, nil
+}
+
 // PrefixBytes returns the two GUID prefix
 // bytes individually
 func (g GUID) PrefixBytes() (byte, byte) {
 	return g[0], g[1]
 }
 
+// IsValid reports whether the GUID is canonical: its prefix bytes are
+// lowercase base36 characters, every field is in range and encoded the
+// way the GUID setters encode it, and it is not the zero GUID.
+func (g GUID) IsValid() bool {
+	if !(isValidPrefixByte(g[0]) && isValidPrefixByte(g[1])) {
+		return false
+	}
+
+	if !g.fieldsInRange() {
+		return false
+	}
+	ts, fingerprint, counter, random := g.fields()
+	if ts == 0 && fingerprint == 0 && counter == 0 && random == 0 {
+		return false
+	}
+
+	// reject stray bytes left outside of the varint encodings
+	canonical := (GUID{g[0], g[1]}).
+		SetTime(time.Unix(0, ts*1e6)).
+		SetFingerprint(int32(fingerprint)).
+		SetCounter(int32(counter)).
+		SetRandom(random)
+
+	return g == canonical
+}
+
+// fields decodes the numeric fields of the GUID
+func (g GUID) fields() (ts, fingerprint, counter, random int64) {
+	ts, _ = binary.Varint(g[tsStart:tsEnd])
+	fingerprint, _ = binary.Varint(g[fpStart:fpEnd])
+	counter, _ = binary.Varint(g[icStart:icEnd])
+	random, _ = binary.Varint(g[rdStart:rdEnd])
+	return
+}
+
+// fieldsInRange reports whether every numeric field of the GUID fits its
+// fixed-width base36 encoding
+func (g GUID) fieldsInRange() bool {
+	ts, fingerprint, counter, random := g.fields()
+	return ts >= 0 && ts < maxTime &&
+		fingerprint >= 0 && fingerprint < maxInt &&
+		counter >= 0 && counter < maxInt &&
+		random >= 0 && random < maxRandom
+}
+
 // SetTime inserts the unix timestamp into the GUID
 func (g GUID) SetTime(t time.Time) GUID {
 	_ = binary.PutVarint(g[tsStart:tsEnd], t.UnixNano()/1e6)
@@ -134,21 +325,30 @@ func (g GUID) Random() int64 {
 	return v
 }
 
+// String returns the canonical 28-character base36 representation of the GUID.
 func (g GUID) String() string {
-	nanos, _ := binary.Varint(g[tsStart:tsEnd])
-	fingerprint, _ := binary.Varint(g[fpStart:fpEnd])
-	counter, _ := binary.Varint(g[icStart:icEnd])
-	random, _ := binary.Varint(g[rdStart:rdEnd])
+	var buf [byteSize]byte
+	return string(g.AppendString(buf[:0]))
+}
 
-	sb := strings.Builder{}
-	sb.Grow(byteSize)
-	sb.Write(g[0:2])
-	sb.WriteString(leftPad(strconv.FormatInt(nanos, base), fieldSize*2))
-	sb.WriteString(leftPad(strconv.FormatInt(fingerprint, base), fieldSize))
-	sb.WriteString(leftPad(strconv.FormatInt(counter, base), fieldSize))
-	sb.WriteString(leftPad(strconv.FormatInt(random, base), rdEnd-rdStart))
+// AppendString appends the canonical string form of the GUID to dst
+// and returns the extended buffer. It does not allocate when dst has
+// sufficient capacity.
+func (g GUID) AppendString(dst []byte) []byte {
+	ts, fingerprint, counter, random := g.fields()
 
-	return sb.String()
+	dst = append(dst, g[0], g[1])
+	dst = appendBase36(dst, ts, fieldSize*2)
+	dst = appendBase36(dst, fingerprint, fieldSize)
+	dst = appendBase36(dst, counter, fieldSize)
+	dst = appendBase36(dst, random, rdEnd-rdStart)
+
+	return dst
+}
+
+// AppendText implements encoding.TextAppender
+func (g GUID) AppendText(dst []byte) ([]byte, error) {
+	return g.AppendString(dst), nil
 }
 
 // Slug returns a shortened version of the GUID that may be used as a
@@ -167,7 +367,8 @@ func (g GUID) Slug() string { //nolint:gocritic // complains about pointer seman
 		  1 2       3 4 5 6 7 8 9 10  11121314  15161718  192021222324252627 28
 		  0 1       2 3 4 5 6 7 8 09  10111213  14151617  181920212223242526 27
 	*/
-	gg := g.String()
+	var buf [byteSize]byte
+	gg := g.AppendString(buf[:0])
 	out := [12]byte{
 		// TIMESTAMP                COUNTER         RANDOM
 		gg[6], gg[7], gg[8], gg[9], gg[16], gg[17], gg[22], gg[23], gg[24], gg[25], gg[26], gg[27],
@@ -177,57 +378,105 @@ func (g GUID) Slug() string { //nolint:gocritic // complains about pointer seman
 
 // Parse the byte slice into a guid
 func Parse(in []byte) (GUID, error) {
+	return parse(in, false)
+}
+
+// ParseString is a convenience func for parsing GUID strings
+func ParseString(s string) (GUID, error) {
+	return parse(s, false)
+}
+
+// ParseStrict parses the byte slice into a guid, rejecting input that is
+// parseable but not canonical: prefix bytes that are not lowercase base36
+// characters, uppercase digits, and the zero GUID.
+func ParseStrict(in []byte) (GUID, error) {
+	return parse(in, true)
+}
+
+// ParseStringStrict is a convenience func for strictly parsing GUID strings
+func ParseStringStrict(s string) (GUID, error) {
+	return parse(s, true)
+}
+
+// Validate reports whether s is a canonical GUID string. It returns nil
+// for valid input and a *ParseError otherwise.
+func Validate(s string) error {
+	_, err := parse(s, true)
+	return err
+}
+
+// textFields describes the base36 fields of the canonical string form
+var textFields = [...]struct {
+	name       string
+	start, end int
+}{
+	{FieldTimestamp, tsStart, tsEnd},
+	{FieldFingerprint, fpStart, fpEnd},
+	{FieldCounter, icStart, icEnd},
+	{FieldRandom, rdStart, rdEnd},
+}
+
+// parse decodes the canonical string form of a GUID in a single pass
+// without allocating on success. Strict mode rejects non-canonical input.
+func parse[T string | []byte](in T, strict bool) (GUID, error) {
 	if len(in) != byteSize {
-		return GUID{}, fmt.Errorf("guid.Parse: the byte slice must be exactly %d bytes in length", byteSize)
+		return GUID{}, &ParseError{
+			Offset: min(len(in), byteSize),
+			Input:  string(in),
+			Err:    ErrInvalidLength,
+		}
 	}
-	g := GUID{}
-	g[0] = in[0]
-	g[1] = in[1]
 
-	t, err := strconv.ParseUint(string(in[tsStart:tsEnd]), base, blockSize)
-	if err != nil {
-		return GUID{}, fmt.Errorf("guid.Parse: invalid time value '%s': %w", in[tsStart:tsEnd], err)
+	digitValues := &base36Values
+	if strict {
+		digitValues = &base36LowerValues
+		for i := 0; i < 2; i++ {
+			if !isValidPrefixByte(in[i]) {
+				return GUID{}, &ParseError{
+					Field:  FieldPrefix,
+					Offset: i,
+					Input:  string(in[0:2]),
+					Err:    ErrInvalidCharacter,
+				}
+			}
+		}
 	}
-	g = g.SetTime(time.Unix(0, int64(t)*1e6))
 
-	fingerprint, err := strconv.ParseUint(string(in[fpStart:fpEnd]), base, blockSize)
-	if err != nil {
-		return GUID{}, fmt.Errorf("guid.Parse: invalid fingerprint value '%s': %w", in[fpStart:fpEnd], err)
+	var values [len(textFields)]int64
+	for i, f := range textFields {
+		v, bad := decodeBase36(in[f.start:f.end], digitValues)
+		if bad >= 0 {
+			return GUID{}, &ParseError{
+				Field:  f.name,
+				Offset: f.start + bad,
+				Input:  string(in[f.start:f.end]),
+				Err:    ErrInvalidCharacter,
+			}
+		}
+		values[i] = v
 	}
-	g = g.SetFingerprint(int32(fingerprint))
 
-	counter, err := strconv.ParseUint(string(in[icStart:icEnd]), base, blockSize)
-	if err != nil {
-		return GUID{}, fmt.Errorf("guid.Parse: invalid counter value '%s': %w", in[icStart:icEnd], err)
+	if strict && values == [len(textFields)]int64{} {
+		return GUID{}, &ParseError{Input: string(in), Err: ErrZeroGUID}
 	}
-	g = g.SetCounter(int32(counter))
 
-	r, err := strconv.ParseUint(string(in[rdStart:rdEnd]), base, blockSize)
-	if err != nil {
-		return GUID{}, fmt.Errorf("guid.Parse: invalid random value '%s': %w", in[rdStart:rdEnd], err)
-	}
-	g = g.SetRandom(int64(r))
+	g := GUID{in[0], in[1]}
+	g = g.SetTime(time.Unix(0, values[0]*1e6))
+	g = g.SetFingerprint(int32(values[1]))
+	g = g.SetCounter(int32(values[2]))
+	g = g.SetRandom(values[3])
 
 	return g, nil
 }
 
-// ParseString is a convenience func for parsing GUID strings
-func ParseString(s string) (GUID, error) {
-	return Parse([]byte(s))
-}
-
 // interface impls
 
 // MarshalJSON implements json.Marshaler
 func (g GUID) MarshalJSON() ([]byte, error) {
-	s := g.String()
-	sz := byteSize + 2
-	b := make([]byte, sz)
-	b[0] = '"'
-	for i := 0; i < byteSize; i++ {
-		b[i+1] = s[i]
-	}
-	b[sz-1] = '"'
+	b := make([]byte, 0, byteSize+2)
+	b = append(b, '"')
+	b = g.AppendString(b)
+	b = append(b, '"')
 
 	return b, nil
 }
@@ -236,7 +485,10 @@ func (g GUID) MarshalJSON() ([]byte, error) {
 func (g *GUID) UnmarshalJSON(b []byte) error {
 	lb := len(b)
 	if lb <= 1 {
-		return fmt.Errorf("guid.GUID.UnmarshalJSON: unable to parse bytes: %s", b)
+		return fmt.Errorf("guid.GUID.UnmarshalJSON: parse error: %w", &ParseError{
+			Input: string(b),
+			Err:   ErrInvalidLength,
+		})
 	}
 	b = b[1 : lb-1]
 	gg, err := Parse(b)
@@ -279,18 +531,64 @@ func (g GUID) Value() (driver.Value, error) {
 	return g.String(), nil
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler
+// MarshalBinary implements encoding.BinaryMarshaler. The binary form is
+// a fixed-length, versioned encoding of the GUID's fields:
+//
+//	version  prefix  timestamp (ms)  fingerprint  counter  random
+//	[b]      [b, b]  [6 bytes]       [3 bytes]    [3 bytes] [7 bytes]
+//
+// All integers are big-endian.
 func (g GUID) MarshalBinary() (data []byte, err error) {
-	data = g[:]
-	return
+	if !g.fieldsInRange() {
+		return nil, fmt.Errorf("guid.GUID.MarshalBinary: %w", ErrOutOfRange)
+	}
+	ts, fingerprint, counter, random := g.fields()
+
+	data = make([]byte, binarySize)
+	data[0] = binaryVersion
+	data[1] = g[0]
+	data[2] = g[1]
+	putUintBE(data[3:9], uint64(ts))
+	putUintBE(data[9:12], uint64(fingerprint))
+	putUintBE(data[12:15], uint64(counter))
+	putUintBE(data[15:22], uint64(random))
+
+	return data, nil
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. In addition
+// to the binary form produced by MarshalBinary, it accepts the canonical
+// string form of a GUID.
 func (g *GUID) UnmarshalBinary(data []byte) error {
-	gg, err := Parse(data)
-	if err != nil {
-		return err
+	if len(data) == byteSize {
+		gg, err := Parse(data)
+		if err != nil {
+			return err
+		}
+		*g = gg
+		return nil
+	}
+
+	if len(data) != binarySize {
+		return fmt.Errorf("guid.GUID.UnmarshalBinary: the byte slice must be exactly %d bytes in length: %w", binarySize, ErrInvalidLength)
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("guid.GUID.UnmarshalBinary: unsupported binary version %d", data[0])
+	}
+
+	ts := uintBE(data[3:9])
+	fingerprint := uintBE(data[9:12])
+	counter := uintBE(data[12:15])
+	random := uintBE(data[15:22])
+	if ts >= maxTime || fingerprint >= maxInt || counter >= maxInt || random >= maxRandom {
+		return fmt.Errorf("guid.GUID.UnmarshalBinary: %w", ErrOutOfRange)
 	}
+
+	gg := GUID{data[1], data[2]}
+	gg = gg.SetTime(time.Unix(0, int64(ts)*1e6))
+	gg = gg.SetFingerprint(int32(fingerprint))
+	gg = gg.SetCounter(int32(counter))
+	gg = gg.SetRandom(int64(random))
 	*g = gg
 
 	return nil
@@ -298,13 +596,17 @@ func (g *GUID) UnmarshalBinary(data []byte) error {
 
 // MarshalText implements encoding.TextMarshaler
 func (g GUID) MarshalText() (text []byte, err error) {
-	text = []byte(g.String())
-	return
+	return g.AppendText(make([]byte, 0, byteSize))
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler
 func (g *GUID) UnmarshalText(text []byte) error {
-	return g.UnmarshalBinary(text)
+	gg, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*g = gg
+	return nil
 }
 
 // GobEncode implements gob.GobEncoder
@@ -1,12 +1,28 @@
 package guid
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 )
 
+// fixedGenerator always generates the same GUID
+type fixedGenerator struct {
+	g GUID
+}
+
+func (f fixedGenerator) Generate() (GUID, error) {
+	return f.g, nil
+}
+
 // the name here is funky because TestGUID is a global convenience var
 func TestGUIDX(t *testing.T) {
 	const (
@@ -101,6 +117,9 @@ func TestGUIDX(t *testing.T) {
 			str         string
 			expectErr   bool
 			errContains string
+			sentinel    error
+			field       string
+			offset      int
 		}
 
 		tests := []test{
@@ -109,37 +128,53 @@ func TestGUIDX(t *testing.T) {
 				str:         "nope",
 				expectErr:   true,
 				errContains: "bytes in length",
+				sentinel:    ErrInvalidLength,
+				offset:      4,
 			},
 			{
 				name:        "too long",
 				str:         "xo000000000000000000000000001",
 				expectErr:   true,
 				errContains: "bytes in length",
+				sentinel:    ErrInvalidLength,
+				offset:      byteSize,
 			},
 			{
 				// pr(2) + ts(8) + fp(4) + ctr(4) + rnd(10) = 28
 				name:        "monkey business: bad time",
 				str:         "xo!0000000000000000000000000000"[:byteSize],
 				expectErr:   true,
-				errContains: "invalid time value",
+				errContains: "invalid timestamp value",
+				sentinel:    ErrInvalidCharacter,
+				field:       FieldTimestamp,
+				offset:      2,
 			},
 			{
 				name:        "monkey business: bad fingerprint",
 				str:         "xo00000000!000000000000000000000"[:byteSize],
 				expectErr:   true,
 				errContains: "invalid fingerprint value",
+				sentinel:    ErrInvalidCharacter,
+				field:       FieldFingerprint,
+				offset:      10,
 			},
 			{
 				name:        "monkey business: bad counter",
 				str:         "xo00000000000000!00000000000000"[:byteSize],
 				expectErr:   true,
 				errContains: "invalid counter value",
+				sentinel:    ErrInvalidCharacter,
+				field:       FieldCounter,
+				offset:      16,
 			},
 			{
 				name:        "monkey business: bad random",
 				str:         "xo000000000000000000!000000000"[:byteSize],
 				expectErr:   true,
 				errContains: "invalid random value",
+				sentinel:    ErrInvalidCharacter,
+				field:       FieldRandom,
+				offset:      20,
 			},
 		}
 
@@ -152,6 +187,19 @@ func TestGUIDX(t *testing.T) {
 						if !strings.Contains(err.Error(), tt.errContains) {
 							t.Fatalf("expected error string [%s] to contain [%s]", err.Error(), tt.errContains)
 						}
+						if !errors.Is(err, tt.sentinel) {
+							t.Fatalf("expected error to match %v", tt.sentinel)
+						}
+						var pe *ParseError
+						if !errors.As(err, &pe) {
+							t.Fatalf("expected *ParseError, got %T", err)
+						}
+						if pe.Field != tt.field {
+							t.Fatalf("expected field '%s', got '%s'", tt.field, pe.Field)
+						}
+						if pe.Offset != tt.offset {
+							t.Fatalf("expected offset %d, got %d", tt.offset, pe.Offset)
+						}
 						return
 					}
 					t.Fatalf("unexpected error: %v", err)
@@ -161,6 +209,25 @@ func TestGUIDX(t *testing.T) {
 				}
 			})
 		}
+
+		t.Run("wrapped by UnmarshalJSON and Scan", func(t *testing.T) {
+			var g GUID
+			var pe *ParseError
+			err := g.UnmarshalJSON([]byte(`"xo00000000!00000000000000000"`))
+			if !errors.As(err, &pe) || pe.Field != FieldFingerprint {
+				t.Fatalf("expected fingerprint *ParseError, got %v", err)
+			}
+			err = g.Scan("xo00000000000000!00000000000")
+			if !errors.As(err, &pe) || pe.Field != FieldCounter {
+				t.Fatalf("expected counter *ParseError, got %v", err)
+			}
+			for _, in := range []string{``, `"`} {
+				err := g.UnmarshalJSON([]byte(in))
+				if !errors.As(err, &pe) || !errors.Is(err, ErrInvalidLength) {
+					t.Fatalf("expected a length *ParseError for %q, got %v", in, err)
+				}
+			}
+		})
 	})
 
 	t.Run("options", func(t *testing.T) {
@@ -213,9 +280,357 @@ func TestGUIDX(t *testing.T) {
 				}
 			}
 		})
+
+		t.Run("WithTime", func(t *testing.T) {
+			backfill := time.Unix(0, ts)
+			g, err := New(WithTime(backfill))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !g.Time().Equal(backfill) {
+				t.Fatalf("expected time %v, got %v", backfill, g.Time())
+			}
+		})
+
+		t.Run("WithFingerprint", func(t *testing.T) {
+			g, err := New(WithFingerprint(1234))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if g.Fingerprint() != 1234 {
+				t.Fatalf("expected fingerprint 1234, got %d", g.Fingerprint())
+			}
+		})
+
+		t.Run("WithRandomSource", func(t *testing.T) {
+			g, err := New(WithRandomSource(newTestReader([8]byte{0, 0, 0, 0, 0, 0, 0, 42})))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if g.Random() != 42 {
+				t.Fatalf("expected random 42, got %d", g.Random())
+			}
+		})
+
+		t.Run("WithGenerator", func(t *testing.T) {
+			gen := MustNewGenerator(WithGeneratorPrefix('g', 'n'), WithCounterStart(77))
+			g, err := New(WithGenerator(gen))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b1, b2 := g.PrefixBytes(); b1 != 'g' || b2 != 'n' {
+				t.Fatalf("expected prefix 'gn', got '%c%c'", b1, b2)
+			}
+			if g.Counter() != 77 {
+				t.Fatalf("expected counter 77, got %d", g.Counter())
+			}
+		})
+
+		t.Run("overrides on other generators", func(t *testing.T) {
+			backfill := time.Unix(0, ts)
+			g, err := New(
+				WithGenerator(fixedGenerator{TestGUID}),
+				WithTime(backfill),
+				WithFingerprint(99),
+				WithRandomSource(newTestReader([8]byte{0, 0, 0, 0, 0, 0, 0, 7})),
+				WithPrefixBytes('o', 'k'),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !g.Time().Equal(backfill) || g.Fingerprint() != 99 || g.Random() != 7 || g.Counter() != TestGUID.Counter() {
+				t.Fatalf("unexpected GUID %s", g)
+			}
+			if b1, b2 := g.PrefixBytes(); b1 != 'o' || b2 != 'k' {
+				t.Fatalf("expected prefix 'ok', got '%c%c'", b1, b2)
+			}
+		})
+
+		t.Run("backfills do not disturb monotonic ordering", func(t *testing.T) {
+			gen := MustNewGenerator(WithMonotonic())
+			g1, err := New(WithGenerator(gen))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := New(WithGenerator(gen), WithTime(time.Unix(0, ts).Add(24*time.Hour*365*100))); err != nil {
+				t.Fatal(err)
+			}
+			g2, err := New(WithGenerator(gen))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !g1.Less(g2) || g2.Time().Sub(g1.Time()) > time.Minute {
+				t.Fatalf("expected %s to follow %s closely", g2, g1)
+			}
+		})
 	})
 }
 
+func TestCodecRoundTrip(t *testing.T) {
+	guids := []GUID{TestGUID, MustNew(), MustNew(WithPrefixBytes('a', 'b'))}
+
+	for _, g := range guids {
+		t.Run(g.String(), func(t *testing.T) {
+			t.Run("binary", func(t *testing.T) {
+				data, err := g.MarshalBinary()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(data) != binarySize {
+					t.Fatalf("expected %d bytes, got %d", binarySize, len(data))
+				}
+				var out GUID
+				if err := out.UnmarshalBinary(data); err != nil {
+					t.Fatal(err)
+				}
+				if out != g {
+					t.Fatalf("expected %s, got %s", g, out)
+				}
+			})
+
+			t.Run("binary accepts text form", func(t *testing.T) {
+				var out GUID
+				if err := out.UnmarshalBinary([]byte(g.String())); err != nil {
+					t.Fatal(err)
+				}
+				if out != g {
+					t.Fatalf("expected %s, got %s", g, out)
+				}
+			})
+
+			t.Run("gob", func(t *testing.T) {
+				type wrapper struct {
+					ID GUID
+				}
+				var buf bytes.Buffer
+				if err := gob.NewEncoder(&buf).Encode(wrapper{ID: g}); err != nil {
+					t.Fatal(err)
+				}
+				var out wrapper
+				if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+					t.Fatal(err)
+				}
+				if out.ID != g {
+					t.Fatalf("expected %s, got %s", g, out.ID)
+				}
+			})
+
+			t.Run("gob accepts text form", func(t *testing.T) {
+				var out GUID
+				if err := out.GobDecode([]byte(g.String())); err != nil {
+					t.Fatal(err)
+				}
+				if out != g {
+					t.Fatalf("expected %s, got %s", g, out)
+				}
+			})
+
+			t.Run("text", func(t *testing.T) {
+				text, err := g.MarshalText()
+				if err != nil {
+					t.Fatal(err)
+				}
+				var out GUID
+				if err := out.UnmarshalText(text); err != nil {
+					t.Fatal(err)
+				}
+				if out != g {
+					t.Fatalf("expected %s, got %s", g, out)
+				}
+			})
+
+			t.Run("json", func(t *testing.T) {
+				data, err := json.Marshal(g)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var out GUID
+				if err := json.Unmarshal(data, &out); err != nil {
+					t.Fatal(err)
+				}
+				if out != g {
+					t.Fatalf("expected %s, got %s", g, out)
+				}
+			})
+
+			t.Run("sql", func(t *testing.T) {
+				v, err := g.Value()
+				if err != nil {
+					t.Fatal(err)
+				}
+				var out GUID
+				if err := out.Scan(v); err != nil {
+					t.Fatal(err)
+				}
+				if out != g {
+					t.Fatalf("expected %s, got %s", g, out)
+				}
+			})
+		})
+	}
+
+	t.Run("invalid binary", func(t *testing.T) {
+		data, err := TestGUID.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out GUID
+		if err := out.UnmarshalBinary(data[:binarySize-1]); err == nil {
+			t.Fatal("expected error for short input")
+		}
+		bad := bytes.Clone(data)
+		bad[0] = 0
+		if err := out.UnmarshalBinary(bad); err == nil {
+			t.Fatal("expected error for unknown version")
+		}
+		bad = bytes.Clone(data)
+		putUintBE(bad[15:22], maxRandom)
+		if err := out.UnmarshalBinary(bad); err == nil {
+			t.Fatal("expected error for out of range random value")
+		}
+		bad = bytes.Clone(data)
+		putUintBE(bad[3:9], maxTime)
+		if err := out.UnmarshalBinary(bad); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange for a timestamp beyond 36^8, got %v", err)
+		}
+	})
+
+	t.Run("text rejects binary", func(t *testing.T) {
+		data, err := TestGUID.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out GUID
+		err = out.UnmarshalText(data)
+		var pe *ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, ErrInvalidLength) {
+			t.Fatalf("expected a length *ParseError, got %v", err)
+		}
+	})
+
+	t.Run("unencodable timestamp", func(t *testing.T) {
+		g := TestGUID.SetTime(time.UnixMilli(-1))
+		if _, err := g.MarshalBinary(); err == nil {
+			t.Fatal("expected error for negative timestamp")
+		}
+		g = (GUID{'i', 'd'}).SetTime(time.UnixMilli(maxTime))
+		if _, err := g.MarshalBinary(); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange for a timestamp beyond 36^8, got %v", err)
+		}
+	})
+}
+
+func TestParseStrict(t *testing.T) {
+	valid := MustNew().String()
+
+	tests := []struct {
+		name     string
+		str      string
+		sentinel error
+		field    string
+		offset   int
+	}{
+		{
+			name: "valid",
+			str:  valid,
+		},
+		{
+			name:     "uppercase prefix",
+			str:      "Id" + valid[2:],
+			sentinel: ErrInvalidCharacter,
+			field:    FieldPrefix,
+			offset:   0,
+		},
+		{
+			name:     "punctuation prefix",
+			str:      "i-" + valid[2:],
+			sentinel: ErrInvalidCharacter,
+			field:    FieldPrefix,
+			offset:   1,
+		},
+		{
+			name:     "uppercase digit",
+			str:      valid[:20] + "Z" + valid[21:],
+			sentinel: ErrInvalidCharacter,
+			field:    FieldRandom,
+			offset:   20,
+		},
+		{
+			name:     "zero GUID",
+			str:      "id00000000000000000000000000",
+			sentinel: ErrZeroGUID,
+		},
+		{
+			name:     "bad length",
+			str:      valid[:10],
+			sentinel: ErrInvalidLength,
+			offset:   10,
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := ParseStringStrict(tt.str)
+			if verr := Validate(tt.str); (verr == nil) != (err == nil) {
+				t.Fatalf("Validate and ParseStringStrict disagree: %v vs %v", verr, err)
+			}
+			if tt.sentinel == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !g.IsValid() {
+					t.Fatalf("expected %s to be valid", g)
+				}
+				return
+			}
+			if !errors.Is(err, tt.sentinel) {
+				t.Fatalf("expected error to match %v, got %v", tt.sentinel, err)
+			}
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected *ParseError, got %T", err)
+			}
+			if pe.Field != tt.field || pe.Offset != tt.offset {
+				t.Fatalf("expected field '%s' at offset %d, got '%s' at %d", tt.field, tt.offset, pe.Field, pe.Offset)
+			}
+			// lenient parsing still accepts everything but bad lengths
+			if _, err := ParseString(tt.str); err != nil && tt.sentinel != ErrInvalidLength {
+				t.Fatalf("expected lenient parse to succeed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGUID_IsValid(t *testing.T) {
+	if !MustNew().IsValid() {
+		t.Fatal("expected generated GUID to be valid")
+	}
+	if !TestGUID.IsValid() {
+		t.Fatal("expected TestGUID to be valid")
+	}
+	if (GUID{}).IsValid() {
+		t.Fatal("expected empty GUID to be invalid")
+	}
+
+	g := MustNew()
+	g[0] = 'A'
+	if g.IsValid() {
+		t.Fatal("expected GUID with uppercase prefix to be invalid")
+	}
+
+	g = MustNew()
+	g[rdEnd-1] = 0xff
+	if g.IsValid() {
+		t.Fatal("expected GUID with stray bytes to be invalid")
+	}
+
+	g = MustNew().SetTime(time.UnixMilli(-1))
+	if g.IsValid() {
+		t.Fatal("expected GUID with negative timestamp to be invalid")
+	}
+}
+
 func TestSlugs(t *testing.T) {
 	// generate GUIDs and verify slug properties
 	for i := 0; i < 20; i++ {
@@ -240,8 +655,119 @@ func TestSlugs(t *testing.T) {
 	}
 }
 
+// legacyString is the strconv-based encoder that String replaced
+func legacyString(g GUID) string {
+	nanos, _ := binary.Varint(g[tsStart:tsEnd])
+	fingerprint, _ := binary.Varint(g[fpStart:fpEnd])
+	counter, _ := binary.Varint(g[icStart:icEnd])
+	random, _ := binary.Varint(g[rdStart:rdEnd])
+
+	sb := strings.Builder{}
+	sb.Write(g[0:2])
+	sb.WriteString(leftPad(strconv.FormatInt(nanos, base), fieldSize*2))
+	sb.WriteString(leftPad(strconv.FormatInt(fingerprint, base), fieldSize))
+	sb.WriteString(leftPad(strconv.FormatInt(counter, base), fieldSize))
+	sb.WriteString(leftPad(strconv.FormatInt(random, base), rdEnd-rdStart))
+
+	return sb.String()
+}
+
+// legacyParse is the strconv-based parser that Parse replaced
+func legacyParse(in []byte) (GUID, error) {
+	if len(in) != byteSize {
+		return GUID{}, fmt.Errorf("bad length")
+	}
+	g := GUID{in[0], in[1]}
+	t, err := strconv.ParseUint(string(in[tsStart:tsEnd]), base, blockSize)
+	if err != nil {
+		return GUID{}, err
+	}
+	g = g.SetTime(time.Unix(0, int64(t)*1e6))
+	fingerprint, err := strconv.ParseUint(string(in[fpStart:fpEnd]), base, blockSize)
+	if err != nil {
+		return GUID{}, err
+	}
+	g = g.SetFingerprint(int32(fingerprint))
+	counter, err := strconv.ParseUint(string(in[icStart:icEnd]), base, blockSize)
+	if err != nil {
+		return GUID{}, err
+	}
+	g = g.SetCounter(int32(counter))
+	r, err := strconv.ParseUint(string(in[rdStart:rdEnd]), base, blockSize)
+	if err != nil {
+		return GUID{}, err
+	}
+	return g.SetRandom(int64(r)), nil
+}
+
+func TestParseAllocs(t *testing.T) {
+	s := MustNew().String()
+	b := []byte(s)
+	j := []byte(`"` + s + `"`)
+
+	if n := testing.AllocsPerRun(100, func() {
+		_, _ = Parse(b)
+	}); n != 0 {
+		t.Fatalf("expected Parse to make 0 allocations, got %v", n)
+	}
+	if n := testing.AllocsPerRun(100, func() {
+		_, _ = ParseString(s)
+	}); n != 0 {
+		t.Fatalf("expected ParseString to make 0 allocations, got %v", n)
+	}
+	var g GUID
+	if n := testing.AllocsPerRun(100, func() {
+		_ = g.UnmarshalJSON(j)
+	}); n != 0 {
+		t.Fatalf("expected UnmarshalJSON to make 0 allocations, got %v", n)
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	rando := rand.New(rand.NewSource(1622222222222000000))
+
+	guids := []GUID{{}, TestGUID, MustNew()}
+	for i := 0; i < 1000; i++ {
+		guids = append(guids, (GUID{'a', 'b'}).
+			SetTime(time.UnixMilli(rando.Int63n(1<<42))).
+			SetFingerprint(rando.Int31()).
+			SetCounter(rando.Int31()).
+			SetRandom(rando.Int63()))
+	}
+	// out of band timestamps use the variable-width encoding
+	guids = append(guids, (GUID{'a', 'b'}).SetTime(time.UnixMilli(-1000)))
+
+	for _, g := range guids {
+		expect := legacyString(g)
+		if s := g.String(); s != expect {
+			t.Fatalf("expected '%s', got '%s'", expect, s)
+		}
+		b, err := g.AppendText([]byte("x:"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "x:"+expect {
+			t.Fatalf("expected 'x:%s', got '%s'", expect, b)
+		}
+	}
+
+	g := MustNew()
+	buf := make([]byte, 0, byteSize)
+	if n := testing.AllocsPerRun(100, func() {
+		buf, _ = g.AppendText(buf[:0])
+	}); n != 0 {
+		t.Fatalf("expected AppendText to make 0 allocations, got %v", n)
+	}
+	if n := testing.AllocsPerRun(100, func() {
+		_ = g.String()
+	}); n > 1 {
+		t.Fatalf("expected String to make at most 1 allocation, got %v", n)
+	}
+}
+
 func BenchmarkParseString(b *testing.B) {
 	str := MustNew().String()
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		_, _ = ParseString(str)
 	}
@@ -249,35 +775,65 @@ func BenchmarkParseString(b *testing.B) {
 
 func BenchmarkParse(b *testing.B) {
 	bt := []byte(MustNew().String())
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		_, _ = Parse(bt)
 	}
 }
 
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	bt, _ := MustNew().MarshalJSON()
+	var g GUID
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = g.UnmarshalJSON(bt)
+	}
+}
+
 func BenchmarkString(b *testing.B) {
 	g1 := MustNew()
 	g2 := MustNew()
 	g3 := MustNew(WithPrefixBytes('a', 'b'))
 
 	b.Run(g1.String(), func(b *testing.B) {
+		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
 			_ = g1.String()
 		}
 	})
 
 	b.Run(g2.String(), func(b *testing.B) {
+		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
 			_ = g2.String()
 		}
 	})
 
 	b.Run(g3.String(), func(b *testing.B) {
+		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
 			_ = g3.String()
 		}
 	})
 }
 
+func BenchmarkAppendText(b *testing.B) {
+	g := MustNew()
+	buf := make([]byte, 0, byteSize)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = g.AppendText(buf[:0])
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	g := MustNew()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.MarshalJSON()
+	}
+}
+
 func FuzzParse(f *testing.F) {
 	// seed with valid GUIDs
 	f.Add([]byte(MustNew().String()))
@@ -288,13 +844,22 @@ func FuzzParse(f *testing.F) {
 	f.Add([]byte("short"))
 	f.Add(make([]byte, byteSize))
 	f.Add(make([]byte, 100))
+	f.Add([]byte(strings.ToUpper(TestGUID.String())))
 
 	f.Fuzz(func(t *testing.T, data []byte) {
 		g, err := Parse(data)
+		// the hand-written decoder must agree with strconv
+		lg, lerr := legacyParse(data)
+		if (err == nil) != (lerr == nil) {
+			t.Fatalf("parse error mismatch: got %v, strconv got %v", err, lerr)
+		}
 		if err != nil {
 			// parsing failure is expected for arbitrary input
 			return
 		}
+		if g != lg {
+			t.Fatalf("parse mismatch: got %v, strconv got %v", g, lg)
+		}
 		// if it parsed, the string should be byteSize chars
 		s := g.String()
 		if len(s) != byteSize {
@@ -0,0 +1,129 @@
+package guidtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/schigh/guid"
+)
+
+// AssertPrefix reports an error if g does not carry the prefix bytes b1
+// and b2. It returns whether the assertion held.
+func AssertPrefix(t testing.TB, g guid.GUID, b1, b2 byte) bool {
+	t.Helper()
+	p1, p2 := g.PrefixBytes()
+	if p1 == b1 && p2 == b2 {
+		return true
+	}
+	t.Errorf("guidtest.AssertPrefix: want prefix %q, got %q\n%s", []byte{b1, b2}, []byte{p1, p2}, describe(g))
+	return false
+}
+
+// AssertCreatedBetween reports an error if the timestamp of g falls
+// outside [start, end]. GUID timestamps have millisecond precision, so
+// start is truncated to the millisecond before comparing. It returns
+// whether the assertion held.
+func AssertCreatedBetween(t testing.TB, g guid.GUID, start, end time.Time) bool {
+	t.Helper()
+	ts := g.Time()
+	if !ts.Before(start.Truncate(time.Millisecond)) && !ts.After(end) {
+		return true
+	}
+	t.Errorf("guidtest.AssertCreatedBetween: want time in [%s, %s], got %s\n%s",
+		start.UTC().Format(timeFormat), end.UTC().Format(timeFormat), ts.UTC().Format(timeFormat), describe(g))
+	return false
+}
+
+// AssertFingerprint reports an error if g does not carry the fingerprint
+// fp. It returns whether the assertion held.
+func AssertFingerprint(t testing.TB, g guid.GUID, fp int32) bool {
+	t.Helper()
+	if g.Fingerprint() == fp {
+		return true
+	}
+	t.Errorf("guidtest.AssertFingerprint: want fingerprint %d, got %d\n%s", fp, g.Fingerprint(), describe(g))
+	return false
+}
+
+// AssertMonotonic reports an error for every GUID in ids that does not
+// sort strictly after its predecessor, as defined by guid.Compare. It
+// returns whether the assertion held.
+func AssertMonotonic(t testing.TB, ids []guid.GUID) bool {
+	t.Helper()
+	ok := true
+	for i := 1; i < len(ids); i++ {
+		if guid.Compare(ids[i-1], ids[i]) < 0 {
+			continue
+		}
+		ok = false
+		t.Errorf("guidtest.AssertMonotonic: GUID %d does not sort after GUID %d\n%s",
+			i, i-1, diff(fmt.Sprintf("[%d]", i-1), fmt.Sprintf("[%d]", i), ids[i-1], ids[i]))
+	}
+	return ok
+}
+
+// AssertUnique reports an error for every GUID in ids that repeats an
+// earlier one. It returns whether the assertion held.
+func AssertUnique(t testing.TB, ids []guid.GUID) bool {
+	t.Helper()
+	ok := true
+	seen := make(map[guid.GUID]int, len(ids))
+	for i, g := range ids {
+		if j, dup := seen[g]; dup {
+			ok = false
+			t.Errorf("guidtest.AssertUnique: GUID %d duplicates GUID %d\n%s", i, j, describe(g))
+			continue
+		}
+		seen[g] = i
+	}
+	return ok
+}
+
+// timeFormat is used to print GUID timestamps, which have millisecond
+// precision
+const timeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// fieldsOf decomposes g into named, printable fields
+func fieldsOf(g guid.GUID) [][2]string {
+	b1, b2 := g.PrefixBytes()
+	return [][2]string{
+		{"string", g.String()},
+		{"prefix", string([]byte{b1, b2})},
+		{"time", g.Time().UTC().Format(timeFormat)},
+		{"fingerprint", fmt.Sprint(g.Fingerprint())},
+		{"counter", fmt.Sprint(g.Counter())},
+		{"random", fmt.Sprint(g.Random())},
+	}
+}
+
+// describe renders the fields of g, one per line
+func describe(g guid.GUID) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	for _, f := range fieldsOf(g) {
+		fmt.Fprintf(w, "  %s\t%s\n", f[0], f[1])
+	}
+	_ = w.Flush()
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// diff renders the fields of a and b side by side, marking the fields
+// that differ
+func diff(aName, bName string, a, b guid.GUID) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  \t%s\t%s\t\n", aName, bName)
+	af, bf := fieldsOf(a), fieldsOf(b)
+	for i := range af {
+		mark := ""
+		if af[i][1] != bf[i][1] {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", af[i][0], af[i][1], bf[i][1], mark)
+	}
+	_ = w.Flush()
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,139 @@
+package guidtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/schigh/guid"
+)
+
+// recorder is a testing.TB that records errors instead of failing
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertPrefix(t *testing.T) {
+	g := NewSequence(Epoch, time.Millisecond)
+	UsePrefix(t, 'a', 'b')
+	id, _ := g.Generate()
+
+	r := &recorder{TB: t}
+	if !AssertPrefix(r, id, 'a', 'b') || len(r.errors) != 0 {
+		t.Fatalf("expected the assertion to hold, got %v", r.errors)
+	}
+	if AssertPrefix(r, id, 'x', 'y') || len(r.errors) != 1 {
+		t.Fatalf("expected one error, got %v", r.errors)
+	}
+	for _, want := range []string{`want prefix "xy", got "ab"`, "fingerprint  1234", id.String()} {
+		if !strings.Contains(r.errors[0], want) {
+			t.Errorf("expected %q in %s", want, r.errors[0])
+		}
+	}
+}
+
+func TestAssertCreatedBetween(t *testing.T) {
+	id := guid.GUID{'i', 'd'}.SetTime(Epoch)
+
+	r := &recorder{TB: t}
+	// the GUID timestamp is truncated, so a start within the same
+	// millisecond still holds
+	if !AssertCreatedBetween(r, id, Epoch.Add(500*time.Microsecond), Epoch.Add(time.Second)) {
+		t.Fatalf("expected the assertion to hold, got %v", r.errors)
+	}
+	if !AssertCreatedBetween(r, id, Epoch, Epoch) {
+		t.Fatalf("expected the assertion to hold, got %v", r.errors)
+	}
+	if AssertCreatedBetween(r, id, Epoch.Add(time.Millisecond), Epoch.Add(time.Second)) {
+		t.Fatal("expected the assertion to fail for a later start")
+	}
+	if AssertCreatedBetween(r, id, Epoch.Add(-time.Second), Epoch.Add(-time.Millisecond)) {
+		t.Fatal("expected the assertion to fail for an earlier end")
+	}
+	if len(r.errors) != 2 {
+		t.Fatalf("expected two errors, got %v", r.errors)
+	}
+	if !strings.Contains(r.errors[0], "got 2021-05-28T17:17:02.222Z") {
+		t.Errorf("unexpected error: %s", r.errors[0])
+	}
+}
+
+func TestAssertFingerprint(t *testing.T) {
+	id := guid.GUID{'i', 'd'}.SetFingerprint(Fingerprint)
+
+	r := &recorder{TB: t}
+	if !AssertFingerprint(r, id, Fingerprint) {
+		t.Fatalf("expected the assertion to hold, got %v", r.errors)
+	}
+	if AssertFingerprint(r, id, 99) || len(r.errors) != 1 {
+		t.Fatalf("expected one error, got %v", r.errors)
+	}
+	if !strings.Contains(r.errors[0], "want fingerprint 99, got 1234") {
+		t.Errorf("unexpected error: %s", r.errors[0])
+	}
+}
+
+func TestAssertMonotonic(t *testing.T) {
+	seq := NewSequence(Epoch, time.Millisecond)
+	ids := make([]guid.GUID, 4)
+	for i := range ids {
+		ids[i], _ = seq.Generate()
+	}
+
+	r := &recorder{TB: t}
+	if !AssertMonotonic(r, ids) || !AssertMonotonic(r, nil) {
+		t.Fatalf("expected the assertion to hold, got %v", r.errors)
+	}
+
+	ids[1], ids[2] = ids[2], ids[1]
+	if AssertMonotonic(r, ids) || len(r.errors) != 1 {
+		t.Fatalf("expected one error, got %v", r.errors)
+	}
+	msg := r.errors[0]
+	if !strings.Contains(msg, "GUID 2 does not sort after GUID 1") {
+		t.Errorf("unexpected error: %s", msg)
+	}
+	// the diff marks the fields that differ and leaves the rest alone
+	for _, line := range strings.Split(msg, "\n")[1:] {
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "string", "time", "counter", "random":
+			if fields[len(fields)-1] != "*" {
+				t.Errorf("expected %s to be marked: %q", fields[0], line)
+			}
+		case "prefix", "fingerprint":
+			if fields[len(fields)-1] == "*" {
+				t.Errorf("expected %s not to be marked: %q", fields[0], line)
+			}
+		}
+	}
+}
+
+func TestAssertUnique(t *testing.T) {
+	seq := NewSequence(Epoch, time.Millisecond)
+	ids := make([]guid.GUID, 3)
+	for i := range ids {
+		ids[i], _ = seq.Generate()
+	}
+
+	r := &recorder{TB: t}
+	if !AssertUnique(r, ids) {
+		t.Fatalf("expected the assertion to hold, got %v", r.errors)
+	}
+
+	ids = append(ids, ids[0], ids[0])
+	if AssertUnique(r, ids) || len(r.errors) != 2 {
+		t.Fatalf("expected two errors, got %v", r.errors)
+	}
+	if !strings.Contains(r.errors[1], "GUID 4 duplicates GUID 0") {
+		t.Errorf("unexpected error: %s", r.errors[1])
+	}
+}
@@ -0,0 +1,25 @@
+package guidtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	c := NewClock(Epoch)
+	if !c.Now().Equal(Epoch) {
+		t.Fatalf("expected %v, got %v", Epoch, c.Now())
+	}
+
+	if now := c.Advance(time.Second); !now.Equal(Epoch.Add(time.Second)) {
+		t.Fatalf("expected %v, got %v", Epoch.Add(time.Second), now)
+	}
+	if now := c.Advance(-2 * time.Second); !now.Equal(Epoch.Add(-time.Second)) {
+		t.Fatalf("expected %v, got %v", Epoch.Add(-time.Second), now)
+	}
+
+	c.Set(Epoch)
+	if !c.Now().Equal(Epoch) {
+		t.Fatalf("expected %v, got %v", Epoch, c.Now())
+	}
+}
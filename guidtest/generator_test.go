@@ -0,0 +1,86 @@
+package guidtest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/schigh/guid"
+)
+
+func TestNewReader(t *testing.T) {
+	a, b := make([]byte, 20), make([]byte, 20)
+	if _, err := NewReader(7).Read(a); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewReader(7).Read(b); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Fatal("expected readers with the same seed to produce the same bytes")
+	}
+	if _, err := NewReader(8).Read(b); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(a, b) {
+		t.Fatal("expected readers with different seeds to produce different bytes")
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	c1, c2 := NewClock(Epoch), NewClock(Epoch)
+	gen1, gen2 := NewGenerator(c1, 42), NewGenerator(c2, 42)
+
+	for i := 0; i < 10; i++ {
+		g1, err := gen1.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		g2, err := gen2.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g1 != g2 {
+			t.Fatalf("expected identical GUIDs, got %s and %s", g1, g2)
+		}
+		if !g1.Time().Equal(c1.Now()) {
+			t.Fatalf("expected time %v, got %v", c1.Now(), g1.Time())
+		}
+		if g1.Fingerprint() != Fingerprint {
+			t.Fatalf("expected fingerprint %d, got %d", Fingerprint, g1.Fingerprint())
+		}
+		c1.Advance(time.Millisecond)
+		c2.Advance(time.Millisecond)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	seq := NewSequence(Epoch, time.Second)
+	for i := 0; i < 5; i++ {
+		g, err := seq.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := Epoch.Add(time.Duration(i) * time.Second); !g.Time().Equal(want) {
+			t.Fatalf("expected time %v, got %v", want, g.Time())
+		}
+		if g.Counter() != int32(i) || g.Random() != int64(i) {
+			t.Fatalf("expected counter and random %d, got %d and %d", i, g.Counter(), g.Random())
+		}
+	}
+
+	seq.Reset()
+	g, err := seq.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Time().Equal(Epoch) || g.Counter() != 0 {
+		t.Fatalf("expected sequence to restart, got %s", g)
+	}
+
+	// a Sequence can drive the global generator
+	UseGenerator(t, NewSequence(Epoch, time.Minute))
+	if g := guid.MustNew(); !g.Time().Equal(Epoch) {
+		t.Fatalf("expected time %v, got %v", Epoch, g.Time())
+	}
+}
@@ -0,0 +1,49 @@
+package guidtest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/schigh/guid"
+)
+
+var update = flag.Bool("guidtest.update", false, "rewrite guidtest golden files")
+
+// AssertGolden compares the string form of got with the golden file at
+// path, which holds one GUID per line. Running the tests with
+// -guidtest.update writes got to the golden file instead.
+func AssertGolden(t testing.TB, path string, got []guid.GUID) {
+	t.Helper()
+
+	lines := make([]string, len(got))
+	for i := range got {
+		lines[i] = got[i].String()
+	}
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("guidtest.AssertGolden: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatalf("guidtest.AssertGolden: %v", err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("guidtest.AssertGolden: %v (run with -guidtest.update to create it)", err)
+	}
+	want := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(want) != len(lines) {
+		t.Fatalf("guidtest.AssertGolden: %s has %d GUIDs, got %d", path, len(want), len(lines))
+	}
+	for i := range want {
+		if want[i] != lines[i] {
+			t.Errorf("guidtest.AssertGolden: GUID %d of %s:\n  want: %s\n   got: %s", i, path, want[i], lines[i])
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package guidtest
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/schigh/guid"
+)
+
+func TestAssertGolden(t *testing.T) {
+	UsePrefix(t, 'g', 'd')
+
+	seq := NewSequence(Epoch, time.Hour)
+	gen := NewGenerator(NewClock(Epoch), 1)
+
+	var got []guid.GUID
+	for i := 0; i < 3; i++ {
+		g, err := seq.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, g)
+	}
+	for i := 0; i < 3; i++ {
+		g, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, g)
+	}
+
+	AssertGolden(t, filepath.Join("testdata", "golden.txt"), got)
+}
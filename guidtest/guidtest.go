@@ -0,0 +1,48 @@
+// Package guidtest provides helpers for testing code that uses the guid
+// package.
+//
+// The helpers that replace global configuration restore it when the test
+// finishes, but the globals are shared by the whole test binary, so tests
+// that use them must not run in parallel with tests that generate GUIDs
+// through the global generator.
+package guidtest
+
+import (
+	"testing"
+
+	"github.com/schigh/guid"
+)
+
+// UseGenerator makes g the global generator for the duration of the test.
+// The previous generator is restored when the test and its subtests
+// complete. The test fails if the globals have been locked with
+// guid.LockGlobals.
+func UseGenerator(t testing.TB, g guid.Generator) {
+	t.Helper()
+	prev, err := guid.SwapGlobalGenerator(g)
+	if err != nil {
+		t.Fatalf("guidtest.UseGenerator: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := guid.SwapGlobalGenerator(prev); err != nil {
+			t.Errorf("guidtest.UseGenerator: restore failed: %v", err)
+		}
+	})
+}
+
+// UsePrefix makes b1 and b2 the global prefix bytes for the duration of
+// the test. The previous prefix is restored when the test and its
+// subtests complete. The test fails if the prefix bytes are invalid or
+// the globals have been locked with guid.LockGlobals.
+func UsePrefix(t testing.TB, b1, b2 byte) {
+	t.Helper()
+	prev, err := guid.SwapGlobalPrefixBytes(b1, b2)
+	if err != nil {
+		t.Fatalf("guidtest.UsePrefix: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := guid.SwapGlobalPrefixBytes(prev[0], prev[1]); err != nil {
+			t.Errorf("guidtest.UsePrefix: restore failed: %v", err)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package guidtest
+
+import (
+	"testing"
+
+	"github.com/schigh/guid"
+)
+
+func TestUseGenerator(t *testing.T) {
+	prev := guid.GlobalGenerator()
+
+	t.Run("swap", func(t *testing.T) {
+		gen := guid.MustNewGenerator(guid.WithCounterStart(4321))
+		UseGenerator(t, gen)
+		if g := guid.MustNew(); g.Counter() != 4321 {
+			t.Fatalf("expected counter 4321, got %d", g.Counter())
+		}
+	})
+
+	if guid.GlobalGenerator() != prev {
+		t.Fatal("expected the previous generator to be restored")
+	}
+}
+
+func TestUsePrefix(t *testing.T) {
+	b1, b2 := guid.GlobalPrefixBytes()
+
+	t.Run("swap", func(t *testing.T) {
+		UsePrefix(t, 't', 't')
+		if p1, p2 := guid.MustNew().PrefixBytes(); p1 != 't' || p2 != 't' {
+			t.Fatalf("expected prefix 'tt', got '%c%c'", p1, p2)
+		}
+
+		// helpers can be used again in the same test binary
+		t.Run("nested", func(t *testing.T) {
+			UsePrefix(t, 'n', 'n')
+			if p1, p2 := guid.MustNew().PrefixBytes(); p1 != 'n' || p2 != 'n' {
+				t.Fatalf("expected prefix 'nn', got '%c%c'", p1, p2)
+			}
+		})
+
+		if p1, p2 := guid.GlobalPrefixBytes(); p1 != 't' || p2 != 't' {
+			t.Fatalf("expected prefix 'tt' after nested test, got '%c%c'", p1, p2)
+		}
+	})
+
+	if p1, p2 := guid.GlobalPrefixBytes(); p1 != b1 || p2 != b2 {
+		t.Fatalf("expected prefix '%c%c' to be restored, got '%c%c'", b1, b2, p1, p2)
+	}
+}
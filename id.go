@@ -0,0 +1,187 @@
+package guid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Kind identifies an entity type and declares the prefix bytes used by
+// its IDs. Kinds are usually empty structs:
+//
+//	type User struct{}
+//
+//	func (User) Prefix() (byte, byte) { return 'u', 's' }
+type Kind interface {
+	Prefix() (byte, byte)
+}
+
+// ID is a GUID bound to the entity type T. An ID[User] cannot be used
+// where an ID[Order] is expected, and parsing or decoding an ID rejects
+// GUIDs whose prefix does not match the kind.
+type ID[T Kind] GUID
+
+// kindPrefix returns the prefix bytes declared by T
+func kindPrefix[T Kind]() (byte, byte) {
+	var k T
+	return k.Prefix()
+}
+
+// NewID creates an ID of kind T using the global generator.
+func NewID[T Kind](opts ...Option) (ID[T], error) {
+	b1, b2 := kindPrefix[T]()
+	g, err := New(append(opts[:len(opts):len(opts)], WithPrefixBytes(b1, b2))...)
+	if err != nil {
+		return ID[T]{}, err
+	}
+	return ID[T](g), nil
+}
+
+// MustNewID creates an ID of kind T or panics on error.
+func MustNewID[T Kind](opts ...Option) ID[T] {
+	id, err := NewID[T](opts...)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// IDFromGUID binds g to the kind T. It returns a *ParseError wrapping
+// ErrPrefixMismatch if the prefix of g does not match the kind.
+func IDFromGUID[T Kind](g GUID) (ID[T], error) {
+	b1, b2 := kindPrefix[T]()
+	if g[0] != b1 || g[1] != b2 {
+		offset := 0
+		if g[0] == b1 {
+			offset = 1
+		}
+		return ID[T]{}, &ParseError{
+			Field:  FieldPrefix,
+			Offset: offset,
+			Input:  string(g[0:2]),
+			Err:    ErrPrefixMismatch,
+		}
+	}
+	return ID[T](g), nil
+}
+
+// ParseID parses s into an ID of kind T.
+func ParseID[T Kind](s string) (ID[T], error) {
+	g, err := ParseString(s)
+	if err != nil {
+		return ID[T]{}, err
+	}
+	return IDFromGUID[T](g)
+}
+
+// MustParseID parses s into an ID of kind T or panics on error.
+func MustParseID[T Kind](s string) ID[T] {
+	id, err := ParseID[T](s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// GUID returns the untyped GUID.
+func (id ID[T]) GUID() GUID {
+	return GUID(id)
+}
+
+// IsZero reports whether the ID is the zero value.
+func (id ID[T]) IsZero() bool {
+	return GUID(id).IsZero()
+}
+
+func (id ID[T]) String() string {
+	return GUID(id).String()
+}
+
+// set binds the decoded GUID to the ID after checking its prefix
+func (id *ID[T]) set(g GUID) error {
+	v, err := IDFromGUID[T](g)
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (id ID[T]) MarshalJSON() ([]byte, error) {
+	return GUID(id).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (id *ID[T]) UnmarshalJSON(b []byte) error {
+	var g GUID
+	if err := g.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	if err := id.set(g); err != nil {
+		return fmt.Errorf("guid.ID.UnmarshalJSON: %w", err)
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner
+func (id *ID[T]) Scan(v any) error {
+	if v == nil {
+		return nil
+	}
+	var g GUID
+	if err := g.Scan(v); err != nil {
+		return err
+	}
+	if err := id.set(g); err != nil {
+		return fmt.Errorf("guid.ID.Scan: %w", err)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer
+func (id ID[T]) Value() (driver.Value, error) {
+	return GUID(id).Value()
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (id ID[T]) MarshalText() ([]byte, error) {
+	return GUID(id).MarshalText()
+}
+
+// AppendText implements encoding.TextAppender
+func (id ID[T]) AppendText(dst []byte) ([]byte, error) {
+	return GUID(id).AppendText(dst)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (id *ID[T]) UnmarshalText(text []byte) error {
+	var g GUID
+	if err := g.UnmarshalText(text); err != nil {
+		return err
+	}
+	return id.set(g)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (id ID[T]) MarshalBinary() ([]byte, error) {
+	return GUID(id).MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (id *ID[T]) UnmarshalBinary(data []byte) error {
+	var g GUID
+	if err := g.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	return id.set(g)
+}
+
+// GobEncode implements gob.GobEncoder
+func (id ID[T]) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder
+func (id *ID[T]) GobDecode(data []byte) error {
+	return id.UnmarshalBinary(data)
+}
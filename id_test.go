@@ -0,0 +1,136 @@
+package guid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type testUser struct{}
+
+func (testUser) Prefix() (byte, byte) { return 'u', 's' }
+
+type testOrder struct{}
+
+func (testOrder) Prefix() (byte, byte) { return 'o', 'r' }
+
+func TestID(t *testing.T) {
+	t.Run("new", func(t *testing.T) {
+		id := MustNewID[testUser]()
+		if b1, b2 := id.GUID().PrefixBytes(); b1 != 'u' || b2 != 's' {
+			t.Fatalf("expected prefix 'us', got '%c%c'", b1, b2)
+		}
+		// the kind prefix wins over a prefix option
+		id = MustNewID[testUser](WithPrefixBytes('x', 'x'))
+		if b1, b2 := id.GUID().PrefixBytes(); b1 != 'u' || b2 != 's' {
+			t.Fatalf("expected prefix 'us', got '%c%c'", b1, b2)
+		}
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		user := MustNewID[testUser]()
+		id, err := ParseID[testUser](user.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != user {
+			t.Fatalf("expected %s, got %s", user, id)
+		}
+
+		_, err = ParseID[testOrder](user.String())
+		if !errors.Is(err, ErrPrefixMismatch) {
+			t.Fatalf("expected ErrPrefixMismatch, got %v", err)
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Field != FieldPrefix || pe.Offset != 0 {
+			t.Fatalf("expected prefix *ParseError at offset 0, got %v", err)
+		}
+
+		if _, err := ParseID[testUser]("nope"); !errors.Is(err, ErrInvalidLength) {
+			t.Fatalf("expected ErrInvalidLength, got %v", err)
+		}
+	})
+
+	t.Run("codecs", func(t *testing.T) {
+		type record struct {
+			User  ID[testUser]
+			Order ID[testOrder]
+		}
+		in := record{User: MustNewID[testUser](), Order: MustNewID[testOrder]()}
+
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out record
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatal(err)
+		}
+		if out != in {
+			t.Fatalf("json round trip mismatch: %v != %v", out, in)
+		}
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+			t.Fatal(err)
+		}
+		out = record{}
+		if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		if out != in {
+			t.Fatalf("gob round trip mismatch: %v != %v", out, in)
+		}
+
+		text, err := in.User.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var user ID[testUser]
+		if err := user.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if user != in.User {
+			t.Fatalf("text round trip mismatch: %s != %s", user, in.User)
+		}
+
+		v, err := in.Order.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var order ID[testOrder]
+		if err := order.Scan(v); err != nil {
+			t.Fatal(err)
+		}
+		if order != in.Order {
+			t.Fatalf("sql round trip mismatch: %s != %s", order, in.Order)
+		}
+	})
+
+	t.Run("codecs reject wrong kinds", func(t *testing.T) {
+		order := MustNewID[testOrder]()
+		var user ID[testUser]
+
+		data, _ := json.Marshal(order)
+		if err := json.Unmarshal(data, &user); !errors.Is(err, ErrPrefixMismatch) {
+			t.Fatalf("expected ErrPrefixMismatch from json, got %v", err)
+		}
+		v, _ := order.Value()
+		if err := user.Scan(v); !errors.Is(err, ErrPrefixMismatch) {
+			t.Fatalf("expected ErrPrefixMismatch from Scan, got %v", err)
+		}
+		text, _ := order.MarshalText()
+		if err := user.UnmarshalText(text); !errors.Is(err, ErrPrefixMismatch) {
+			t.Fatalf("expected ErrPrefixMismatch from UnmarshalText, got %v", err)
+		}
+		bin, _ := order.MarshalBinary()
+		if err := user.GobDecode(bin); !errors.Is(err, ErrPrefixMismatch) {
+			t.Fatalf("expected ErrPrefixMismatch from GobDecode, got %v", err)
+		}
+		if !user.IsZero() {
+			t.Fatalf("expected ID to be left unchanged, got %s", user)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package guid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrPrefixRegistered indicates that a prefix is already registered.
+	ErrPrefixRegistered = errors.New("guid: prefix already registered")
+
+	// ErrRegistryFrozen indicates that a registry no longer accepts registrations.
+	ErrRegistryFrozen = errors.New("guid: prefix registry is frozen")
+)
+
+// DefaultPrefixRegistry is the registry consulted by GUID.Kind.
+var DefaultPrefixRegistry = NewPrefixRegistry()
+
+// PrefixEntry describes the entity that a prefix belongs to.
+type PrefixEntry struct {
+	Prefix      [2]byte
+	Name        string
+	Description string
+}
+
+// PrefixRegistry maps GUID prefixes to entity names. Registering every
+// prefix an application uses in one place catches collisions at startup.
+// A PrefixRegistry is safe for concurrent use.
+type PrefixRegistry struct {
+	mu      sync.RWMutex
+	entries map[[2]byte]PrefixEntry
+	frozen  bool
+}
+
+// NewPrefixRegistry creates an empty PrefixRegistry.
+func NewPrefixRegistry() *PrefixRegistry {
+	return &PrefixRegistry{
+		entries: make(map[[2]byte]PrefixEntry),
+	}
+}
+
+// Register maps the prefix bytes to an entity name and an optional
+// description. Prefix bytes must be lowercase base36 characters, the name
+// must not be empty, and each prefix can be registered only once.
+func (r *PrefixRegistry) Register(b1, b2 byte, name, description string) error {
+	if !(isValidPrefixByte(b1) && isValidPrefixByte(b2)) {
+		return fmt.Errorf("guid.PrefixRegistry.Register: prefix bytes must be base36-compatible and lowercase")
+	}
+	if name == "" {
+		return fmt.Errorf("guid.PrefixRegistry.Register: name must not be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.frozen {
+		return fmt.Errorf("guid.PrefixRegistry.Register: %w", ErrRegistryFrozen)
+	}
+	pfx := [2]byte{b1, b2}
+	if existing, ok := r.entries[pfx]; ok {
+		return fmt.Errorf("guid.PrefixRegistry.Register: '%s' is registered to %s: %w", pfx[:], existing.Name, ErrPrefixRegistered)
+	}
+	r.entries[pfx] = PrefixEntry{Prefix: pfx, Name: name, Description: description}
+
+	return nil
+}
+
+// MustRegister calls Register and panics on error.
+func (r *PrefixRegistry) MustRegister(b1, b2 byte, name, description string) {
+	if err := r.Register(b1, b2, name, description); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the entry registered for the prefix bytes.
+func (r *PrefixRegistry) Lookup(b1, b2 byte) (PrefixEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.entries[[2]byte{b1, b2}]
+	return e, ok
+}
+
+// Entries returns every registered entry, ordered by prefix.
+func (r *PrefixRegistry) Entries() []PrefixEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]PrefixEntry, 0, len(r.entries))
+	for _, e := range r.entries {
+		out = append(out, e)
+	}
+	slices.SortFunc(out, func(a, b PrefixEntry) int {
+		return slices.Compare(a.Prefix[:], b.Prefix[:])
+	})
+
+	return out
+}
+
+// Freeze stops the registry from accepting further registrations.
+// It is typically called once all prefixes are registered at startup.
+func (r *PrefixRegistry) Freeze() {
+	r.mu.Lock()
+	r.frozen = true
+	r.mu.Unlock()
+}
+
+// Frozen reports whether the registry has been frozen.
+func (r *PrefixRegistry) Frozen() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.frozen
+}
+
+// RegisterPrefix registers a prefix with the DefaultPrefixRegistry.
+func RegisterPrefix(b1, b2 byte, name, description string) error {
+	return DefaultPrefixRegistry.Register(b1, b2, name, description)
+}
+
+// Kind looks up the entity that the GUID's prefix is registered to
+// in the DefaultPrefixRegistry.
+func (g GUID) Kind() (PrefixEntry, bool) {
+	return DefaultPrefixRegistry.Lookup(g[0], g[1])
+}
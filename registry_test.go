@@ -0,0 +1,106 @@
+package guid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestPrefixRegistry(t *testing.T) {
+	r := NewPrefixRegistry()
+
+	if err := r.Register('u', 's', "user", "application users"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register('o', 'r', "order", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("lookup", func(t *testing.T) {
+		e, ok := r.Lookup('u', 's')
+		if !ok {
+			t.Fatal("expected 'us' to be registered")
+		}
+		if e.Name != "user" || e.Description != "application users" || e.Prefix != [2]byte{'u', 's'} {
+			t.Fatalf("unexpected entry %+v", e)
+		}
+		if _, ok := r.Lookup('x', 'x'); ok {
+			t.Fatal("expected 'xx' to be unregistered")
+		}
+	})
+
+	t.Run("entries are ordered by prefix", func(t *testing.T) {
+		entries := r.Entries()
+		if len(entries) != 2 || entries[0].Name != "order" || entries[1].Name != "user" {
+			t.Fatalf("unexpected entries %+v", entries)
+		}
+	})
+
+	t.Run("invalid registrations", func(t *testing.T) {
+		if err := r.Register('u', 's', "other", ""); !errors.Is(err, ErrPrefixRegistered) {
+			t.Fatalf("expected ErrPrefixRegistered, got %v", err)
+		}
+		if err := r.Register('U', 's', "upper", ""); err == nil {
+			t.Fatal("expected error for invalid prefix bytes")
+		}
+		if err := r.Register('n', 'o', "", ""); err == nil {
+			t.Fatal("expected error for empty name")
+		}
+	})
+
+	t.Run("freeze", func(t *testing.T) {
+		r.Freeze()
+		if !r.Frozen() {
+			t.Fatal("expected registry to be frozen")
+		}
+		if err := r.Register('p', 'r', "product", ""); !errors.Is(err, ErrRegistryFrozen) {
+			t.Fatalf("expected ErrRegistryFrozen, got %v", err)
+		}
+		if _, ok := r.Lookup('u', 's'); !ok {
+			t.Fatal("expected lookups to keep working after freezing")
+		}
+	})
+}
+
+func TestPrefixRegistryConcurrency(t *testing.T) {
+	r := NewPrefixRegistry()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	registered := 0
+	for i := 0; i < 36; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// every goroutine races for the same prefix and one of its own
+			if r.Register('z', 'z', "shared", "") == nil {
+				mu.Lock()
+				registered++
+				mu.Unlock()
+			}
+			if err := r.Register('y', digits[i], "own", ""); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			_, _ = r.Lookup('z', 'z')
+		}(i)
+	}
+	wg.Wait()
+	if registered != 1 {
+		t.Fatalf("expected exactly one successful registration, got %d", registered)
+	}
+	if n := len(r.Entries()); n != 37 {
+		t.Fatalf("expected 37 entries, got %d", n)
+	}
+}
+
+func TestGUID_Kind(t *testing.T) {
+	if err := RegisterPrefix('k', 'd', "kind test", ""); err != nil {
+		t.Fatal(err)
+	}
+	e, ok := MustNew(WithPrefixBytes('k', 'd')).Kind()
+	if !ok || e.Name != "kind test" {
+		t.Fatalf("expected kind 'kind test', got %+v", e)
+	}
+	if _, ok := MustNew(WithPrefixBytes('k', 'x')).Kind(); ok {
+		t.Fatal("expected unregistered prefix to have no kind")
+	}
+}
@@ -0,0 +1,88 @@
+package guid
+
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+	"runtime"
+	"sync/atomic"
+)
+
+// maxShards is the largest number of counter shards. It must be a
+// power of two that divides maxInt (36^4 = 2^8 * 3^8).
+const maxShards = 256
+
+// shardedGenerator generates GUIDs without a global lock. The counter
+// space is split between shards: shard i only emits counter values that
+// are congruent to i modulo the shard count, so GUIDs from different
+// shards never share a counter value.
+type shardedGenerator struct {
+	std    *stdGenerator
+	shards []counterShard
+	// span is the number of counter values owned by each shard
+	span uint32
+}
+
+type counterShard struct {
+	n atomic.Uint32
+
+	// keep shards on separate cache lines
+	_ [60]byte
+}
+
+// NewShardedGenerator creates a Generator that scales with GOMAXPROCS by
+// spreading its counter across independent atomic shards instead of
+// serializing on a mutex. It accepts the same options as NewGenerator,
+// except WithMonotonic and WithClockPolicy, since sharded generation makes
+// no ordering guarantees. WithCounterStart is ignored. The random source
+// must be safe for concurrent use.
+func NewShardedGenerator(opts ...GeneratorOption) (Generator, error) {
+	std := newStdGenerator()
+	for i := range opts {
+		if err := opts[i](std); err != nil {
+			return nil, err
+		}
+	}
+	if std.Monotonic || std.ClockPolicy != ClockIgnore {
+		return nil, fmt.Errorf("guid.NewShardedGenerator: monotonic mode and clock policies are not supported")
+	}
+
+	n := 1
+	for n < runtime.GOMAXPROCS(0) && n < maxShards {
+		n <<= 1
+	}
+
+	return &shardedGenerator{
+		std:    std,
+		shards: make([]counterShard, n),
+		span:   uint32(maxInt / n),
+	}, nil
+}
+
+// counter reserves a counter value from a randomly chosen shard
+func (g *shardedGenerator) counter() int32 {
+	id := randv2.IntN(len(g.shards))
+	local := (g.shards[id].n.Add(1) - 1) % g.span
+	return int32(local)*int32(len(g.shards)) + int32(id)
+}
+
+// Generate will create a new GUID.
+func (g *shardedGenerator) Generate() (GUID, error) {
+	return g.generateRequest(&request{})
+}
+
+// generateRequest creates a new GUID, honoring the overrides in req.
+func (g *shardedGenerator) generateRequest(req *request) (GUID, error) {
+	counter := g.counter()
+
+	r, err := randomInt64(req.randomOr(g.std.Random))
+	if err != nil {
+		return GUID{}, err
+	}
+
+	ms := g.std.Now().UnixNano() / 1e6
+	if req.hasTime {
+		ms = req.time.UnixNano() / 1e6
+	}
+
+	return g.std.build(ms, counter, req.fingerprintOr(g.std.Fingerprint), r), nil
+}
@@ -0,0 +1,86 @@
+package guid
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestShardedGeneration(t *testing.T) {
+	const goroutines = 100
+	const perGoroutine = 100
+
+	gen, err := NewShardedGenerator(WithGeneratorPrefix('s', 'h'))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := make(chan GUID, goroutines*perGoroutine)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				g, err := gen.Generate()
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				results <- g
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	seenGUIDs := make(map[GUID]struct{}, goroutines*perGoroutine)
+	seenCounters := make(map[int32]struct{}, goroutines*perGoroutine)
+	for g := range results {
+		if b1, b2 := g.PrefixBytes(); b1 != 's' || b2 != 'h' {
+			t.Fatalf("expected prefix 'sh', got '%c%c'", b1, b2)
+		}
+		if _, exists := seenGUIDs[g]; exists {
+			t.Fatalf("duplicate GUID detected: %s", g)
+		}
+		seenGUIDs[g] = struct{}{}
+
+		// fewer GUIDs than counter values were generated,
+		// so no counter value may repeat
+		c := g.Counter()
+		if c < 0 || c >= maxInt {
+			t.Fatalf("counter %d out of range", c)
+		}
+		if _, exists := seenCounters[c]; exists {
+			t.Fatalf("duplicate counter detected: %d", c)
+		}
+		seenCounters[c] = struct{}{}
+	}
+}
+
+func TestShardedGeneratorCounterWrap(t *testing.T) {
+	gen := &shardedGenerator{
+		std:    newStdGenerator(),
+		shards: make([]counterShard, 4),
+		span:   maxInt / 4,
+	}
+	gen.shards[0].n.Store(maxInt/4 - 1)
+	gen.shards[1].n.Store(maxInt/4 - 1)
+	gen.shards[2].n.Store(maxInt/4 - 1)
+	gen.shards[3].n.Store(maxInt/4 - 1)
+
+	for i := 0; i < 8; i++ {
+		c := gen.counter()
+		if c < 0 || c >= maxInt {
+			t.Fatalf("counter %d out of range", c)
+		}
+	}
+}
+
+func TestShardedGeneratorOptions(t *testing.T) {
+	if _, err := NewShardedGenerator(WithMonotonic()); err == nil {
+		t.Fatal("expected error for monotonic mode")
+	}
+	if _, err := NewShardedGenerator(WithClockPolicy(ClockWait)); err == nil {
+		t.Fatal("expected error for clock policy")
+	}
+}
@@ -0,0 +1,84 @@
+package guid
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// shortPrefixBits is the width of the prefix in the short form, which
+	// stores the index of the two base36 prefix digits (36^2 < 2^11)
+	shortPrefixBits = 11
+
+	// shortBits is the number of significant bits in the short form
+	shortBits = shortPrefixBits + tsBits + 2*intBits + randomBits
+)
+
+// ShortEncoding is a reversible 25-character base62 form of a GUID. It
+// packs the fields more tightly than the sortable binary form by storing
+// the prefix as the index of its two base36 digits, which saves one
+// character over Base62Encoding but only supports GUIDs whose prefix
+// bytes are lowercase base36 characters. Like Base62Encoding, it is
+// case-sensitive, and it sorts like Compare.
+var ShortEncoding Encoding = newRadixEncoding("ShortEncoding", base62Alphabet, nil, false, packing{
+	bits:   shortBits,
+	size:   (shortBits + 7) / 8,
+	pack:   packShort,
+	unpack: unpackShort,
+})
+
+// ShortString returns the 25-character short form of the GUID. Unlike
+// Slug, the short form is reversible with ParseShort. It returns an error
+// if the prefix bytes are not lowercase base36 characters or a field is
+// out of range.
+func (g GUID) ShortString() (string, error) {
+	return ShortEncoding.EncodeToString(g)
+}
+
+// ParseShort parses the short form produced by GUID.ShortString.
+func ParseShort(s string) (GUID, error) {
+	return ShortEncoding.DecodeString(s)
+}
+
+// packShort packs the fields of g into a big-endian integer of shortBits bits
+func packShort(g GUID) ([]byte, error) {
+	p1, p2 := base36LowerValues[g[0]], base36LowerValues[g[1]]
+	if p1 == invalidDigit || p2 == invalidDigit {
+		return nil, fmt.Errorf("invalid prefix %q: %w", g[:2], ErrInvalidCharacter)
+	}
+	if !g.fieldsInRange() {
+		return nil, ErrOutOfRange
+	}
+	ts, fingerprint, counter, random := g.fields()
+
+	data := make([]byte, (shortBits+7)/8)
+	w := bitWriter{buf: data}
+	w.write(0, len(data)*8-shortBits)
+	w.write(uint64(p1)*base+uint64(p2), shortPrefixBits)
+	w.write(uint64(ts), tsBits)
+	w.write(uint64(fingerprint), intBits)
+	w.write(uint64(counter), intBits)
+	w.write(uint64(random), randomBits)
+
+	return data, nil
+}
+
+// unpackShort reverses packShort
+func unpackShort(data []byte) (GUID, error) {
+	r := bitReader{buf: data}
+	_ = r.read(len(data)*8 - shortBits)
+	prefix := r.read(shortPrefixBits)
+	ts := r.read(tsBits)
+	fingerprint := r.read(intBits)
+	counter := r.read(intBits)
+	random := r.read(randomBits)
+	if prefix >= base*base || ts >= maxTime || fingerprint >= maxInt || counter >= maxInt || random >= maxRandom {
+		return GUID{}, ErrOutOfRange
+	}
+
+	return (GUID{digits[prefix/base], digits[prefix%base]}).
+		SetTime(time.Unix(0, int64(ts)*1e6)).
+		SetFingerprint(int32(fingerprint)).
+		SetCounter(int32(counter)).
+		SetRandom(int64(random)), nil
+}
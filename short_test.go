@@ -0,0 +1,80 @@
+package guid
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShortString(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		rando := rand.New(rand.NewSource(1622222222222000000))
+		guids := []GUID{TestGUID, MustNew(), MinForTime(time.Now(), [2]byte{'0', '0'}), MaxForTime(time.Now(), [2]byte{'z', 'z'})}
+		for i := 0; i < 500; i++ {
+			guids = append(guids, randomGUID(rando))
+		}
+
+		prev := ""
+		for i, g := range guids {
+			s, err := g.ShortString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(s) != 25 {
+				t.Fatalf("expected 25 characters, got %d: %s", len(s), s)
+			}
+			out, err := ParseShort(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != g.String() {
+				t.Fatalf("expected %s, got %s", g, out)
+			}
+
+			if i > 4 {
+				if want := sign(Compare(guids[i-1], g)); sign(strings.Compare(prev, s)) != want {
+					t.Fatalf("expected Compare(%s, %s) = %d", prev, s, want)
+				}
+			}
+			prev = s
+		}
+	})
+
+	t.Run("invalid GUIDs", func(t *testing.T) {
+		if _, err := (GUID{'I', 'D'}).ShortString(); !errors.Is(err, ErrInvalidCharacter) {
+			t.Fatalf("expected ErrInvalidCharacter, got %v", err)
+		}
+		if _, err := (GUID{'i', 'd'}).SetTime(time.Unix(0, -1e6)).ShortString(); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange, got %v", err)
+		}
+	})
+}
+
+func TestParseShort(t *testing.T) {
+	s, err := TestGUID.ShortString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		in      string
+		wantErr error
+	}{
+		{"empty", "", ErrInvalidLength},
+		{"base62 encoding", strings.Repeat("0", 26), ErrInvalidLength},
+		{"invalid character", s[:24] + "+", ErrInvalidCharacter},
+		{"too large", strings.Repeat("z", 25), ErrOutOfRange},
+		// the prefix index is 1296, one past zz
+		{"prefix out of range", "AqTONzFe3pGj5VaSbPzRwnd3o", ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseShort(tt.in); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package guid
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrSlugNotFound indicates that no recorded GUID has the slug.
+	ErrSlugNotFound = errors.New("guid: slug not found")
+
+	// ErrAmbiguousSlug is matched by every *AmbiguousSlugError.
+	ErrAmbiguousSlug = errors.New("guid: ambiguous slug")
+)
+
+// AmbiguousSlugError is returned when more than one recorded GUID has
+// the slug being resolved.
+type AmbiguousSlugError struct {
+	Slug string
+	// Matches are the GUIDs with the slug, in the order they were added.
+	Matches []GUID
+}
+
+func (e *AmbiguousSlugError) Error() string {
+	return fmt.Sprintf("guid.SlugIndex.Resolve: slug '%s' matches %d GUIDs", e.Slug, len(e.Matches))
+}
+
+// Unwrap allows errors.Is to match ErrAmbiguousSlug.
+func (e *AmbiguousSlugError) Unwrap() error {
+	return ErrAmbiguousSlug
+}
+
+// SlugIndex maps slugs back to the GUIDs they were created from. Because
+// slugs are lossy, several GUIDs may share a slug; the index keeps all of
+// them and reports the conflict when the slug is resolved. The zero value
+// is an empty index ready to use, and a SlugIndex is safe for concurrent use.
+type SlugIndex struct {
+	mu    sync.RWMutex
+	slugs map[string][]GUID
+	// guids holds every recorded GUID in the order it was added
+	guids []GUID
+}
+
+// NewSlugIndex creates an empty SlugIndex.
+func NewSlugIndex() *SlugIndex {
+	return &SlugIndex{}
+}
+
+// Add records g under its slug. It reports whether g was added, which is
+// false if g had already been recorded.
+func (x *SlugIndex) Add(g GUID) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.add(g)
+}
+
+// add records g. The caller must hold x.mu.
+func (x *SlugIndex) add(g GUID) bool {
+	if x.slugs == nil {
+		x.slugs = make(map[string][]GUID)
+	}
+	slug := g.Slug()
+	if slices.Contains(x.slugs[slug], g) {
+		return false
+	}
+	x.slugs[slug] = append(x.slugs[slug], g)
+	x.guids = append(x.guids, g)
+	return true
+}
+
+// Resolve returns the GUID recorded under slug. It returns an error
+// matching ErrSlugNotFound if no GUID has the slug, and an
+// *AmbiguousSlugError if more than one does.
+func (x *SlugIndex) Resolve(slug string) (GUID, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	matches := x.slugs[slug]
+	switch len(matches) {
+	case 0:
+		return GUID{}, fmt.Errorf("guid.SlugIndex.Resolve: '%s': %w", slug, ErrSlugNotFound)
+	case 1:
+		return matches[0], nil
+	}
+	return GUID{}, &AmbiguousSlugError{Slug: slug, Matches: slices.Clone(matches)}
+}
+
+// Ambiguous returns every slug that maps to more than one GUID, along
+// with the GUIDs it maps to.
+func (x *SlugIndex) Ambiguous() map[string][]GUID {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	out := make(map[string][]GUID)
+	for slug, matches := range x.slugs {
+		if len(matches) > 1 {
+			out[slug] = slices.Clone(matches)
+		}
+	}
+	return out
+}
+
+// Len returns the number of GUIDs in the index.
+func (x *SlugIndex) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.guids)
+}
+
+// Rebuild replaces the contents of the index with the GUIDs read from r,
+// which holds canonical GUID strings separated by whitespace. If r
+// contains an invalid GUID, the index is left unchanged.
+func (x *SlugIndex) Rebuild(r io.Reader) error {
+	var fresh SlugIndex
+	if err := fresh.read(r); err != nil {
+		return fmt.Errorf("guid.SlugIndex.Rebuild: %w", err)
+	}
+
+	x.mu.Lock()
+	x.slugs, x.guids = fresh.slugs, fresh.guids
+	x.mu.Unlock()
+
+	return nil
+}
+
+// read adds the GUIDs read from r. The caller must not share x.
+func (x *SlugIndex) read(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanWords)
+	for i := 0; sc.Scan(); i++ {
+		g, err := ParseString(sc.Text())
+		if err != nil {
+			return fmt.Errorf("GUID %d: %w", i, err)
+		}
+		x.add(g)
+	}
+	return sc.Err()
+}
+
+// write writes the GUIDs in x to f, one per line in the order they were
+// added, and closes f. The caller must not share x.
+func (x *SlugIndex) write(f *os.File) error {
+	w := bufio.NewWriter(f)
+	buf := make([]byte, 0, byteSize+1)
+	for _, g := range x.guids {
+		if _, err := w.Write(append(g.AppendString(buf[:0]), '\n')); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// FileSlugIndex is a SlugIndex persisted to an append-only file of GUID
+// strings, one per line. Opening the file replays it into memory. A
+// FileSlugIndex is safe for concurrent use within a single process.
+type FileSlugIndex struct {
+	index SlugIndex
+	path  string
+
+	// mu serializes writes to f
+	mu sync.Mutex
+	f  *os.File
+}
+
+// OpenFileSlugIndex opens the index stored at path, creating the file if
+// it does not exist.
+//
+// A final line without a newline is what an interrupted Add leaves
+// behind, so it is repaired rather than rejected: if it holds valid GUIDs
+// they are kept and the newline is written, and otherwise the line is
+// truncated from the file. An invalid GUID anywhere else is an error.
+func OpenFileSlugIndex(path string) (*FileSlugIndex, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("guid.OpenFileSlugIndex: %w", err)
+	}
+
+	x := &FileSlugIndex{path: path, f: f}
+	if err := x.replay(); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("guid.OpenFileSlugIndex: %s: %w", path, err)
+	}
+
+	return x, nil
+}
+
+// replay reads the file into the index, repairing a torn final line
+func (x *FileSlugIndex) replay() error {
+	data, err := io.ReadAll(x.f)
+	if err != nil {
+		return err
+	}
+
+	end := bytes.LastIndexByte(data, '\n') + 1
+	if err := x.index.read(bytes.NewReader(data[:end])); err != nil {
+		return err
+	}
+	if end == len(data) {
+		return nil
+	}
+
+	var tail SlugIndex
+	if err := tail.read(bytes.NewReader(data[end:])); err != nil || len(tail.guids) == 0 {
+		return x.f.Truncate(int64(end))
+	}
+	for _, g := range tail.guids {
+		x.index.add(g)
+	}
+	_, err = x.f.Write([]byte{'\n'})
+	return err
+}
+
+// Add records g under its slug and appends it to the file. It reports
+// whether g was added, which is false if g had already been recorded.
+func (x *FileSlugIndex) Add(g GUID) (bool, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.f == nil {
+		return false, fmt.Errorf("guid.FileSlugIndex.Add: %w", os.ErrClosed)
+	}
+
+	x.index.mu.Lock()
+	defer x.index.mu.Unlock()
+	if slices.Contains(x.index.slugs[g.Slug()], g) {
+		return false, nil
+	}
+	info, err := x.f.Stat()
+	if err != nil {
+		return false, fmt.Errorf("guid.FileSlugIndex.Add: %w", err)
+	}
+	if _, err := x.f.Write(append(g.AppendString(make([]byte, 0, byteSize+1)), '\n')); err != nil {
+		// drop a partial line so the next GUID doesn't run into it
+		if terr := x.f.Truncate(info.Size()); terr != nil {
+			err = errors.Join(err, terr)
+		}
+		return false, fmt.Errorf("guid.FileSlugIndex.Add: %w", err)
+	}
+
+	return x.index.add(g), nil
+}
+
+// Resolve returns the GUID recorded under slug. See SlugIndex.Resolve.
+func (x *FileSlugIndex) Resolve(slug string) (GUID, error) {
+	return x.index.Resolve(slug)
+}
+
+// Ambiguous returns every slug that maps to more than one GUID. See
+// SlugIndex.Ambiguous.
+func (x *FileSlugIndex) Ambiguous() map[string][]GUID {
+	return x.index.Ambiguous()
+}
+
+// Len returns the number of GUIDs in the index.
+func (x *FileSlugIndex) Len() int {
+	return x.index.Len()
+}
+
+// Rebuild replaces the contents of the index and its file with the GUIDs
+// read from r. The file is rewritten atomically, in the order the GUIDs
+// are read and with its permissions preserved; if r contains an invalid
+// GUID, the index and file are left unchanged.
+func (x *FileSlugIndex) Rebuild(r io.Reader) error {
+	var fresh SlugIndex
+	if err := fresh.read(r); err != nil {
+		return fmt.Errorf("guid.FileSlugIndex.Rebuild: %w", err)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.f == nil {
+		return fmt.Errorf("guid.FileSlugIndex.Rebuild: %w", os.ErrClosed)
+	}
+
+	// CreateTemp makes the file private, so carry over the current mode
+	info, err := x.f.Stat()
+	if err != nil {
+		return fmt.Errorf("guid.FileSlugIndex.Rebuild: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(x.path), filepath.Base(x.path)+".*")
+	if err != nil {
+		return fmt.Errorf("guid.FileSlugIndex.Rebuild: %w", err)
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("guid.FileSlugIndex.Rebuild: %w", err)
+	}
+	if err := fresh.write(tmp); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("guid.FileSlugIndex.Rebuild: %w", err)
+	}
+
+	// open the new file before it replaces the old one, so a failure
+	// leaves the index and file unchanged
+	f, err := os.OpenFile(tmp.Name(), os.O_RDWR|os.O_APPEND, 0)
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("guid.FileSlugIndex.Rebuild: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), x.path); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("guid.FileSlugIndex.Rebuild: %w", err)
+	}
+	_ = x.f.Close()
+	x.f = f
+
+	x.index.mu.Lock()
+	x.index.slugs, x.index.guids = fresh.slugs, fresh.guids
+	x.index.mu.Unlock()
+
+	return nil
+}
+
+// Close closes the index file. The in-memory index remains readable.
+func (x *FileSlugIndex) Close() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.f == nil {
+		return nil
+	}
+	err := x.f.Close()
+	x.f = nil
+	return err
+}
@@ -0,0 +1,60 @@
+package guid
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestFileSlugIndexFailedAdd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slugs")
+	a, b, c := MustNew(), MustNew(), MustNew()
+
+	x, err := OpenFileSlugIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = x.Close() }()
+	if _, err := x.Add(a); err != nil {
+		t.Fatal(err)
+	}
+
+	// cap the file size partway through the next line, so the write
+	// is cut short
+	var limit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_FSIZE, &limit); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Setrlimit(syscall.RLIMIT_FSIZE, &syscall.Rlimit{Cur: byteSize + 11, Max: limit.Max}); err != nil {
+		t.Skip(err)
+	}
+	_, err = x.Add(b)
+	if rerr := syscall.Setrlimit(syscall.RLIMIT_FSIZE, &limit); rerr != nil {
+		t.Fatal(rerr)
+	}
+	if err == nil {
+		t.Fatal("expected the write to fail")
+	}
+
+	if _, err := x.Add(c); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := a.String() + "\n" + c.String() + "\n"; string(data) != want {
+		t.Fatalf("expected file %q, got %q", want, data)
+	}
+	if err := x.Close(); err != nil {
+		t.Fatal(err)
+	}
+	x, err = OpenFileSlugIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Len() != 2 {
+		t.Fatalf("expected 2 GUIDs, got %d", x.Len())
+	}
+}
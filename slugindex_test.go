@@ -0,0 +1,287 @@
+package guid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slugTwins returns two distinct GUIDs that share a slug
+func slugTwins() (GUID, GUID) {
+	base := (GUID{'i', 'd'}).SetTime(time.UnixMilli(1622222222222)).SetCounter(7).SetRandom(99)
+	return base.SetFingerprint(1), base.SetFingerprint(2)
+}
+
+func TestSlugIndex(t *testing.T) {
+	a, b := slugTwins()
+	if a.Slug() != b.Slug() {
+		t.Fatal("expected the twins to share a slug")
+	}
+	c := MustNew()
+
+	var x SlugIndex
+	if _, err := x.Resolve(c.Slug()); !errors.Is(err, ErrSlugNotFound) {
+		t.Fatalf("expected ErrSlugNotFound, got %v", err)
+	}
+
+	if !x.Add(a) || !x.Add(c) || x.Add(a) {
+		t.Fatal("expected new GUIDs to be added once")
+	}
+	if x.Len() != 2 {
+		t.Fatalf("expected 2 GUIDs, got %d", x.Len())
+	}
+	for _, g := range []GUID{a, c} {
+		got, err := x.Resolve(g.Slug())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != g {
+			t.Fatalf("expected %s, got %s", g, got)
+		}
+	}
+	if len(x.Ambiguous()) != 0 {
+		t.Fatalf("expected no ambiguous slugs, got %v", x.Ambiguous())
+	}
+
+	x.Add(b)
+	_, err := x.Resolve(a.Slug())
+	var ae *AmbiguousSlugError
+	if !errors.As(err, &ae) || !errors.Is(err, ErrAmbiguousSlug) {
+		t.Fatalf("expected an AmbiguousSlugError, got %v", err)
+	}
+	if ae.Slug != a.Slug() || len(ae.Matches) != 2 || ae.Matches[0] != a || ae.Matches[1] != b {
+		t.Fatalf("unexpected error detail %+v", ae)
+	}
+
+	amb := x.Ambiguous()
+	if len(amb) != 1 || len(amb[a.Slug()]) != 2 {
+		t.Fatalf("expected one ambiguous slug, got %v", amb)
+	}
+}
+
+func TestSlugIndexRebuild(t *testing.T) {
+	a, b := slugTwins()
+	c := MustNew()
+
+	x := NewSlugIndex()
+	x.Add(MustNew())
+
+	if err := x.Rebuild(strings.NewReader(a.String() + "\n" + c.String() + " " + b.String() + "\n\n")); err != nil {
+		t.Fatal(err)
+	}
+	if x.Len() != 3 {
+		t.Fatalf("expected 3 GUIDs, got %d", x.Len())
+	}
+	if got, err := x.Resolve(c.Slug()); err != nil || got != c {
+		t.Fatalf("expected %s, got %s (%v)", c, got, err)
+	}
+
+	err := x.Rebuild(strings.NewReader(c.String() + "\nnot-a-guid\n"))
+	if !errors.Is(err, ErrInvalidLength) || !strings.Contains(err.Error(), "GUID 1") {
+		t.Fatalf("expected an invalid length error for GUID 1, got %v", err)
+	}
+	if x.Len() != 3 {
+		t.Fatalf("expected the index to be unchanged, got %d GUIDs", x.Len())
+	}
+}
+
+func TestSlugIndexConcurrency(t *testing.T) {
+	x := NewSlugIndex()
+	guids, err := NewBatch(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, g := range guids {
+				x.Add(g)
+				_, _ = x.Resolve(g.Slug())
+			}
+		}()
+	}
+	wg.Wait()
+
+	if x.Len() != len(guids) {
+		t.Fatalf("expected %d GUIDs, got %d", len(guids), x.Len())
+	}
+}
+
+func TestFileSlugIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slugs")
+	a, b := slugTwins()
+	c := MustNew()
+
+	x, err := OpenFileSlugIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range []GUID{a, b, c, c} {
+		if _, err := x.Add(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := x.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := x.Add(MustNew()); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("expected os.ErrClosed, got %v", err)
+	}
+
+	// the file is replayed on open
+	x, err = OpenFileSlugIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer x.Close()
+	if x.Len() != 3 {
+		t.Fatalf("expected 3 GUIDs, got %d", x.Len())
+	}
+	if got, err := x.Resolve(c.Slug()); err != nil || got != c {
+		t.Fatalf("expected %s, got %s (%v)", c, got, err)
+	}
+	if _, err := x.Resolve(a.Slug()); !errors.Is(err, ErrAmbiguousSlug) {
+		t.Fatalf("expected ErrAmbiguousSlug, got %v", err)
+	}
+	if len(x.Ambiguous()) != 1 {
+		t.Fatalf("expected one ambiguous slug, got %v", x.Ambiguous())
+	}
+
+	// rebuilding rewrites the file
+	if err := x.Rebuild(strings.NewReader(c.String())); err != nil {
+		t.Fatal(err)
+	}
+	d := MustNew()
+	if added, err := x.Add(d); err != nil || !added {
+		t.Fatalf("expected %s to be added, got %v", d, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := c.String() + "\n" + d.String() + "\n"; string(data) != want {
+		t.Fatalf("expected file %q, got %q", want, data)
+	}
+
+	if err := x.Rebuild(strings.NewReader("bad")); err == nil {
+		t.Fatal("expected an error for an invalid GUID")
+	}
+	if x.Len() != 2 {
+		t.Fatalf("expected the index to be unchanged, got %d GUIDs", x.Len())
+	}
+}
+
+func TestFileSlugIndexRebuild(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slugs")
+	x, err := OpenFileSlugIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = x.Close() }()
+	if err := os.Chmod(path, 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := slugTwins()
+	guids := []GUID{b}
+	for i := 0; i < 50; i++ {
+		guids = append(guids, MustNew())
+	}
+	guids = append(guids, a)
+	var in strings.Builder
+	for _, g := range guids {
+		in.WriteString(g.String() + "\n")
+	}
+	if err := x.Rebuild(strings.NewReader(in.String())); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o640 {
+		t.Fatalf("expected mode 0640 to be kept, got %v", info.Mode().Perm())
+	}
+
+	// the file keeps the order the GUIDs were added in, so a reopened
+	// index reports matches in the same order
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != in.String() {
+		t.Fatalf("expected file %q, got %q", in.String(), data)
+	}
+	if err := x.Close(); err != nil {
+		t.Fatal(err)
+	}
+	x, err = OpenFileSlugIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = x.Resolve(a.Slug())
+	var ae *AmbiguousSlugError
+	if !errors.As(err, &ae) || len(ae.Matches) != 2 || ae.Matches[0] != b || ae.Matches[1] != a {
+		t.Fatalf("expected matches [%s %s], got %v", b, a, err)
+	}
+}
+
+func TestOpenFileSlugIndexCorrupt(t *testing.T) {
+	a, b, c := MustNew(), MustNew(), MustNew()
+
+	tests := []struct {
+		name    string
+		content string
+		// after opening and adding c
+		want    string
+		wantLen int
+	}{
+		{"torn final line", a.String() + "\n" + b.String()[:10], a.String() + "\n" + c.String() + "\n", 2},
+		{"unterminated final GUID", a.String() + "\n" + b.String(), a.String() + "\n" + b.String() + "\n" + c.String() + "\n", 3},
+		{"blank final line", a.String() + "\n  ", a.String() + "\n" + c.String() + "\n", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "slugs")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			x, err := OpenFileSlugIndex(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = x.Close() }()
+			if _, err := x.Add(c); err != nil {
+				t.Fatal(err)
+			}
+			if x.Len() != tt.wantLen {
+				t.Fatalf("expected %d GUIDs, got %d", tt.wantLen, x.Len())
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Fatalf("expected file %q, got %q", tt.want, data)
+			}
+		})
+	}
+
+	t.Run("invalid line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "slugs")
+		if err := os.WriteFile(path, []byte(a.String()+"\nidbad\n"+b.String()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := OpenFileSlugIndex(path); !errors.Is(err, ErrInvalidLength) {
+			t.Fatalf("expected ErrInvalidLength, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package guid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+const (
+	// sortableSize is the size of the sortable binary form, in bytes
+	sortableSize = 19
+
+	// bit widths of the fields in the sortable binary form
+	prefixBits = 16
+	tsBits     = 42 // 36^8 < 2^42
+	intBits    = 21 // 36^4 < 2^21
+	randomBits = 52 // 36^10 < 2^52
+)
+
+// bitWriter packs unsigned integers into a byte slice, most significant bit first
+type bitWriter struct {
+	buf []byte
+	n   int
+}
+
+func (w *bitWriter) write(v uint64, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		if v>>uint(i)&1 == 1 {
+			w.buf[w.n/8] |= 0x80 >> uint(w.n%8)
+		}
+		w.n++
+	}
+}
+
+// bitReader unpacks unsigned integers written by bitWriter
+type bitReader struct {
+	buf []byte
+	n   int
+}
+
+func (r *bitReader) read(bits int) uint64 {
+	var v uint64
+	for i := 0; i < bits; i++ {
+		v = v<<1 | uint64(r.buf[r.n/8]>>uint(7-r.n%8)&1)
+		r.n++
+	}
+	return v
+}
+
+// MarshalSortable returns the sortable binary form of the GUID: a 19-byte,
+// big-endian, fixed-width packing of the prefix (16 bits), timestamp
+// (42 bits), fingerprint (21 bits), counter (21 bits) and random (52 bits)
+// fields. Unlike the GUID's internal bytes, the sortable form orders
+// byte-wise exactly like Compare, so it can be stored in BINARY or BYTEA
+// columns and range-scanned by time.
+func (g GUID) MarshalSortable() ([]byte, error) {
+	if !g.fieldsInRange() {
+		return nil, fmt.Errorf("guid.GUID.MarshalSortable: %w", ErrOutOfRange)
+	}
+	ts, fingerprint, counter, random := g.fields()
+
+	w := bitWriter{buf: make([]byte, sortableSize)}
+	w.write(uint64(g[0])<<8|uint64(g[1]), prefixBits)
+	w.write(uint64(ts), tsBits)
+	w.write(uint64(fingerprint), intBits)
+	w.write(uint64(counter), intBits)
+	w.write(uint64(random), randomBits)
+
+	return w.buf, nil
+}
+
+// UnmarshalSortable decodes the sortable binary form produced by
+// MarshalSortable into the GUID.
+func (g *GUID) UnmarshalSortable(data []byte) error {
+	if len(data) != sortableSize {
+		return fmt.Errorf("guid.GUID.UnmarshalSortable: the byte slice must be exactly %d bytes in length: %w", sortableSize, ErrInvalidLength)
+	}
+
+	r := bitReader{buf: data}
+	prefix := r.read(prefixBits)
+	ts := r.read(tsBits)
+	fingerprint := r.read(intBits)
+	counter := r.read(intBits)
+	random := r.read(randomBits)
+	if ts >= maxTime || fingerprint >= maxInt || counter >= maxInt || random >= maxRandom {
+		return fmt.Errorf("guid.GUID.UnmarshalSortable: %w", ErrOutOfRange)
+	}
+
+	*g = (GUID{byte(prefix >> 8), byte(prefix)}).
+		SetTime(time.Unix(0, int64(ts)*1e6)).
+		SetFingerprint(int32(fingerprint)).
+		SetCounter(int32(counter)).
+		SetRandom(int64(random))
+
+	return nil
+}
+
+// Sortable adapts a GUID for database/sql so that it is stored in its
+// sortable binary form rather than as a string. Convert with
+// guid.Sortable(g) and guid.GUID(s).
+type Sortable GUID
+
+// Value implements driver.Valuer
+func (s Sortable) Value() (driver.Value, error) {
+	return GUID(s).MarshalSortable()
+}
+
+// Scan implements sql.Scanner
+func (s *Sortable) Scan(v any) error {
+	if v == nil {
+		return nil
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		return fmt.Errorf("guid.Sortable.Scan: unable to convert value of type %T", v)
+	}
+	var g GUID
+	if err := g.UnmarshalSortable(b); err != nil {
+		return fmt.Errorf("guid.Sortable.Scan: %w", err)
+	}
+	*s = Sortable(g)
+
+	return nil
+}
+
+// String returns the canonical string form of the underlying GUID.
+func (s Sortable) String() string {
+	return GUID(s).String()
+}
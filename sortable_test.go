@@ -0,0 +1,117 @@
+package guid
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSortable(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, g := range []GUID{TestGUID, MustNew(), MinForTime(time.Now(), [2]byte{'a', 'b'}), MaxForTime(time.Now(), [2]byte{'z', 'z'})} {
+			data, err := g.MarshalSortable()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(data) != sortableSize {
+				t.Fatalf("expected %d bytes, got %d", sortableSize, len(data))
+			}
+			var out GUID
+			if err := out.UnmarshalSortable(data); err != nil {
+				t.Fatal(err)
+			}
+			if out != g {
+				t.Fatalf("expected %s, got %s", g, out)
+			}
+		}
+	})
+
+	t.Run("byte order matches Compare", func(t *testing.T) {
+		rando := rand.New(rand.NewSource(1622222222222000000))
+		for i := 0; i < 10000; i++ {
+			a, b := randomGUID(rando), randomGUID(rando)
+			ab, err := a.MarshalSortable()
+			if err != nil {
+				t.Fatal(err)
+			}
+			bb, err := b.MarshalSortable()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c, bc := Compare(a, b), bytes.Compare(ab, bb); c != bc {
+				t.Fatalf("Compare(%s, %s) = %d, sortable comparison = %d", a, b, c, bc)
+			}
+		}
+	})
+
+	t.Run("byte order matches time order", func(t *testing.T) {
+		gen := MustNewGenerator(WithMonotonic())
+		var prev []byte
+		for i := 0; i < 1000; i++ {
+			g, err := gen.Generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := g.MarshalSortable()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prev != nil && bytes.Compare(prev, data) >= 0 {
+				t.Fatalf("expected sortable bytes of %s to sort after their predecessor", g)
+			}
+			prev = data
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := MustNew().SetTime(time.UnixMilli(-1)).MarshalSortable(); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange, got %v", err)
+		}
+		var g GUID
+		if err := g.UnmarshalSortable(make([]byte, sortableSize-1)); !errors.Is(err, ErrInvalidLength) {
+			t.Fatalf("expected ErrInvalidLength, got %v", err)
+		}
+		if err := g.UnmarshalSortable(bytes.Repeat([]byte{0xff}, sortableSize)); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange, got %v", err)
+		}
+
+		// timestamps that fit in 42 bits but not in 8 base36 digits
+		if _, err := (GUID{'i', 'd'}).SetTime(time.UnixMilli(maxTime)).MarshalSortable(); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange, got %v", err)
+		}
+		w := bitWriter{buf: make([]byte, sortableSize)}
+		w.write('i'<<8|'d', prefixBits)
+		w.write(maxTime, tsBits)
+		if err := g.UnmarshalSortable(w.buf); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange, got %v", err)
+		}
+	})
+}
+
+func TestSortable_SQL(t *testing.T) {
+	g := MustNew()
+	v, err := Sortable(g).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v.([]byte); !ok {
+		t.Fatalf("expected []byte value, got %T", v)
+	}
+
+	var s Sortable
+	if err := s.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if GUID(s) != g {
+		t.Fatalf("expected %s, got %s", g, s)
+	}
+	if s.String() != g.String() {
+		t.Fatalf("expected string %s, got %s", g, s.String())
+	}
+
+	if err := s.Scan(g.String()); err == nil {
+		t.Fatal("expected error when scanning a string")
+	}
+}
@@ -0,0 +1,150 @@
+package guid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// UUID is a 128-bit RFC 9562 UUID.
+type UUID [16]byte
+
+const (
+	// uuidStringSize is the length of the hyphenated UUID string form
+	uuidStringSize = 36
+
+	// bit widths of the fields in the UUIDv8 form of a GUID
+	uuidTimeBits   = 48
+	uuidPrefixBits = 12 // 36^2 < 2^12
+	uuidRandomBits = 20
+
+	// uuidVariant is the RFC 9562 variant, 0b10
+	uuidVariant = 0x2
+)
+
+// LossyUUID converts the GUID to a UUIDv8. The layout keeps the timestamp
+// in the high bits, so UUIDs of GUIDs sort by time like UUIDv7:
+//
+//	unix_ms  ver  prefix  var  fingerprint  counter  random
+//	[48]     [4]  [12]    [2]  [21]         [21]     [20]
+//
+// The prefix is stored as the index of its two base36 digits.
+//
+// As the name says, the conversion is lossy, and no lossless form exists:
+// a GUID carries about 145 bits of information and a UUID only has room
+// for 122, so only the low 20 bits of the random field are kept.
+// FromLossyUUID restores the prefix, timestamp, fingerprint and counter
+// exactly, and the random field modulo 2^20. GUIDs that differ only in
+// the high bits of their random field map to the same UUID, so the UUID
+// must not stand in for the GUID as a unique key.
+func (g GUID) LossyUUID() (UUID, error) {
+	var u UUID
+
+	p1, p2 := base36LowerValues[g[0]], base36LowerValues[g[1]]
+	if p1 == invalidDigit || p2 == invalidDigit {
+		return u, fmt.Errorf("guid.GUID.LossyUUID: invalid prefix %q: %w", g[:2], ErrInvalidCharacter)
+	}
+	if !g.fieldsInRange() {
+		return u, fmt.Errorf("guid.GUID.LossyUUID: %w", ErrOutOfRange)
+	}
+	ts, fingerprint, counter, random := g.fields()
+
+	w := bitWriter{buf: u[:]}
+	w.write(uint64(ts), uuidTimeBits)
+	w.write(8, 4)
+	w.write(uint64(p1)*base+uint64(p2), uuidPrefixBits)
+	w.write(uuidVariant, 2)
+	w.write(uint64(fingerprint), intBits)
+	w.write(uint64(counter), intBits)
+	w.write(uint64(random), uuidRandomBits)
+
+	return u, nil
+}
+
+// FromLossyUUID converts a UUIDv8 produced by GUID.LossyUUID back to a
+// GUID. The result is generally not the original GUID; see
+// GUID.LossyUUID for which parts of it are recovered.
+func FromLossyUUID(u UUID) (GUID, error) {
+	if v := u.Version(); v != 8 {
+		return GUID{}, fmt.Errorf("guid.FromLossyUUID: unsupported UUID version %d", v)
+	}
+	if u[8]>>6 != uuidVariant {
+		return GUID{}, fmt.Errorf("guid.FromLossyUUID: unsupported UUID variant")
+	}
+
+	r := bitReader{buf: u[:]}
+	ts := r.read(uuidTimeBits)
+	_ = r.read(4)
+	prefix := r.read(uuidPrefixBits)
+	_ = r.read(2)
+	fingerprint := r.read(intBits)
+	counter := r.read(intBits)
+	random := r.read(uuidRandomBits)
+	if ts >= maxTime || prefix >= base*base || fingerprint >= maxInt || counter >= maxInt {
+		return GUID{}, fmt.Errorf("guid.FromLossyUUID: %w", ErrOutOfRange)
+	}
+
+	return (GUID{digits[prefix/base], digits[prefix%base]}).
+		SetTime(time.Unix(0, int64(ts)*1e6)).
+		SetFingerprint(int32(fingerprint)).
+		SetCounter(int32(counter)).
+		SetRandom(int64(random)), nil
+}
+
+// Version returns the version number stored in the UUID.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// String returns the canonical hyphenated, lowercase form of the UUID.
+func (u UUID) String() string {
+	var buf [uuidStringSize]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// ParseUUID parses a UUID in the canonical hyphenated form. Hex digits
+// may be upper or lower case.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != uuidStringSize {
+		return u, fmt.Errorf("guid.ParseUUID: the string must be exactly %d characters in length: %w", uuidStringSize, ErrInvalidLength)
+	}
+	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("guid.ParseUUID: malformed UUID '%s': %w", s, ErrInvalidCharacter)
+	}
+
+	src := [...]struct{ from, to, at int }{
+		{0, 8, 0}, {9, 13, 4}, {14, 18, 6}, {19, 23, 8}, {24, 36, 10},
+	}
+	for _, seg := range src {
+		if _, err := hex.Decode(u[seg.at:], []byte(s[seg.from:seg.to])); err != nil {
+			return UUID{}, fmt.Errorf("guid.ParseUUID: malformed UUID '%s': %w", s, ErrInvalidCharacter)
+		}
+	}
+
+	return u, nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (u *UUID) UnmarshalText(text []byte) error {
+	uu, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = uu
+	return nil
+}
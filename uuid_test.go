@@ -0,0 +1,148 @@
+package guid
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestLossyUUID(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, g := range []GUID{TestGUID, MustNew(), MinForTime(time.Now(), [2]byte{'0', '0'}), MaxForTime(time.Now(), [2]byte{'z', 'z'})} {
+			u, err := g.LossyUUID()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Version() != 8 {
+				t.Fatalf("expected version 8, got %d", u.Version())
+			}
+			if u[8]>>6 != 0x2 {
+				t.Fatalf("expected the RFC 9562 variant, got %08b", u[8])
+			}
+
+			out, err := FromLossyUUID(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// everything but the high bits of the random field survives
+			want := (GUID{g[0], g[1]}).
+				SetTime(g.Time()).
+				SetFingerprint(g.Fingerprint()).
+				SetCounter(g.Counter()).
+				SetRandom(g.Random() % (1 << uuidRandomBits))
+			if out != want {
+				t.Fatalf("expected %s, got %s", want, out)
+			}
+		}
+	})
+
+	t.Run("high random bits are dropped", func(t *testing.T) {
+		a := (GUID{'i', 'd'}).SetTime(TestGUID.Time()).SetRandom(1)
+		b := a.SetRandom(1 + 1<<uuidRandomBits)
+		ua, _ := a.LossyUUID()
+		ub, _ := b.LossyUUID()
+		if ua != ub {
+			t.Fatalf("expected %s and %s to share a UUID, got %s and %s", a, b, ua, ub)
+		}
+	})
+
+	t.Run("timestamp in the high bits", func(t *testing.T) {
+		g := TestGUID
+		u, err := g.LossyUUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ms := uintBE(u[:6]); ms != uint64(g.Time().UnixMilli()) {
+			t.Fatalf("expected %d, got %d", g.Time().UnixMilli(), ms)
+		}
+	})
+
+	t.Run("byte order follows time", func(t *testing.T) {
+		rando := rand.New(rand.NewSource(1622222222222000000))
+		for i := 0; i < 1000; i++ {
+			a, b := randomGUID(rando), randomGUID(rando)
+			ua, _ := a.LossyUUID()
+			ub, _ := b.LossyUUID()
+			if a.Time().Before(b.Time()) && bytes.Compare(ua[:], ub[:]) >= 0 {
+				t.Fatalf("expected %s < %s", ua, ub)
+			}
+		}
+	})
+
+	t.Run("invalid GUIDs", func(t *testing.T) {
+		if _, err := Nil.LossyUUID(); !errors.Is(err, ErrInvalidCharacter) {
+			t.Fatalf("expected ErrInvalidCharacter, got %v", err)
+		}
+		if _, err := (GUID{'I', 'D'}).LossyUUID(); !errors.Is(err, ErrInvalidCharacter) {
+			t.Fatalf("expected ErrInvalidCharacter, got %v", err)
+		}
+		if _, err := (GUID{'i', 'd'}).SetTime(time.Unix(0, -1e6)).LossyUUID(); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange, got %v", err)
+		}
+	})
+
+	t.Run("foreign UUIDs", func(t *testing.T) {
+		// a UUIDv4
+		u, err := ParseUUID("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := FromLossyUUID(u); err == nil {
+			t.Fatal("expected an error for a UUIDv4")
+		}
+
+		// a UUIDv8 whose prefix index is out of range
+		u, _ = TestGUID.LossyUUID()
+		u[6] |= 0x0f
+		u[7] = 0xff
+		if _, err := FromLossyUUID(u); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange, got %v", err)
+		}
+	})
+}
+
+func TestUUIDString(t *testing.T) {
+	u, err := TestGUID.LossyUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := u.String()
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-8[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(s) {
+		t.Fatalf("unexpected UUID string %s", s)
+	}
+
+	parsed, err := ParseUUID(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != u {
+		t.Fatalf("expected %s, got %s", u, parsed)
+	}
+
+	var text UUID
+	if err := text.UnmarshalText(bytes.ToUpper([]byte(s))); err != nil {
+		t.Fatal(err)
+	}
+	if text != u {
+		t.Fatalf("expected %s, got %s", u, text)
+	}
+
+	tests := []struct {
+		in      string
+		wantErr error
+	}{
+		{"", ErrInvalidLength},
+		{s[:35], ErrInvalidLength},
+		{s[:8] + "_" + s[9:], ErrInvalidCharacter},
+		{"g" + s[1:], ErrInvalidCharacter},
+		{s[:35] + "x", ErrInvalidCharacter},
+	}
+	for _, tt := range tests {
+		if _, err := ParseUUID(tt.in); !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseUUID(%q): expected %v, got %v", tt.in, tt.wantErr, err)
+		}
+	}
+}
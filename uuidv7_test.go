@@ -0,0 +1,198 @@
+package guid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUUIDv7Generator(t *testing.T) {
+	ts := int64(1600000000000000000)
+	offset := new(int64)
+	clock := func() time.Time {
+		return time.Unix(0, ts+*offset*1e6)
+	}
+
+	t.Run("fields", func(t *testing.T) {
+		gen := MustNewUUIDv7Generator(
+			WithClock(clock),
+			WithGeneratorFingerprint(1234),
+			WithRandomReader(newTestReader([8]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})),
+		)
+		u, err := gen.GenerateUUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Version() != 7 {
+			t.Fatalf("expected version 7, got %d", u.Version())
+		}
+		if u[8]>>6 != 0x2 {
+			t.Fatalf("expected the RFC 9562 variant, got %08b", u[8])
+		}
+		if ms := uintBE(u[:6]); ms != uint64(ts/1e6) {
+			t.Fatalf("expected unix_ms %d, got %d", ts/1e6, ms)
+		}
+
+		f, err := u.V7()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := V7Fields{
+			Time:        time.Unix(0, ts),
+			Counter:     0,
+			Fingerprint: 1234,
+			Random:      1<<uuidV7RandomBits - 1,
+		}
+		if f != want {
+			t.Fatalf("expected %+v, got %+v", want, f)
+		}
+	})
+
+	t.Run("monotonic", func(t *testing.T) {
+		*offset = 0
+		gen := MustNewUUIDv7Generator(WithClock(clock))
+
+		var prev UUID
+		for i := 0; i < 1<<uuidV7CounterBits+10; i++ {
+			u, err := gen.GenerateUUID()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Compare(prev[:], u[:]) >= 0 {
+				t.Fatalf("UUID %d: expected %s > %s", i, u, prev)
+			}
+			prev = u
+		}
+
+		// the counter was exhausted, so the timestamp moved ahead of the clock
+		f, _ := prev.V7()
+		if f.Time.UnixNano() != ts+1e6 || f.Counter != 9 {
+			t.Fatalf("expected counter 9 at %v, got %d at %v", time.Unix(0, ts+1e6), f.Counter, f.Time)
+		}
+
+		// a regressed clock reuses the last timestamp
+		*offset = -5
+		u, err := gen.GenerateUUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Compare(prev[:], u[:]) >= 0 {
+			t.Fatalf("expected %s > %s", u, prev)
+		}
+
+		// a new millisecond restarts the counter
+		*offset = 2
+		u, err = gen.GenerateUUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f, _ := u.V7(); f.Counter != 0 || f.Time.UnixNano() != ts+2e6 {
+			t.Fatalf("expected counter 0 at %v, got %+v", time.Unix(0, ts+2e6), f)
+		}
+	})
+
+	t.Run("clock error", func(t *testing.T) {
+		*offset = 10
+		gen := MustNewUUIDv7Generator(WithClock(clock), WithClockPolicy(ClockError))
+		if _, err := gen.GenerateUUID(); err != nil {
+			t.Fatal(err)
+		}
+		*offset = 4
+		if _, err := gen.GenerateUUID(); !errors.Is(err, ErrClockRegression) {
+			t.Fatalf("expected ErrClockRegression, got %v", err)
+		}
+	})
+
+	t.Run("counter exhaustion is not a regression", func(t *testing.T) {
+		for _, policy := range []ClockPolicy{ClockError, ClockWait} {
+			*offset = 0
+			gen := MustNewUUIDv7Generator(
+				WithClock(clock),
+				WithClockPolicy(policy),
+				WithClockRegressionHook(func(d time.Duration) {
+					t.Fatalf("policy %d: unexpected regression of %v", policy, d)
+				}),
+			)
+			gen.(*uuidV7Generator).std.sleep = func(time.Duration) {
+				t.Fatalf("policy %d: unexpected sleep", policy)
+			}
+
+			var prev UUID
+			for i := 0; i < 2<<uuidV7CounterBits+10; i++ {
+				u, err := gen.GenerateUUID()
+				if err != nil {
+					t.Fatalf("policy %d: UUID %d: %v", policy, i, err)
+				}
+				if bytes.Compare(prev[:], u[:]) >= 0 {
+					t.Fatalf("policy %d: UUID %d: expected %s > %s", policy, i, u, prev)
+				}
+				prev = u
+			}
+			if f, _ := prev.V7(); f.Time.UnixNano() != ts+2e6 || f.Counter != 9 {
+				t.Fatalf("policy %d: expected counter 9 at %v, got %+v", policy, time.Unix(0, ts+2e6), f)
+			}
+
+			// the clock catching up continues from the borrowed timestamp
+			*offset = 1
+			u, err := gen.GenerateUUID()
+			if err != nil {
+				t.Fatalf("policy %d: %v", policy, err)
+			}
+			if bytes.Compare(prev[:], u[:]) >= 0 {
+				t.Fatalf("policy %d: expected %s > %s", policy, u, prev)
+			}
+		}
+	})
+
+	t.Run("fingerprint matches GUIDs", func(t *testing.T) {
+		// set directly, as the default fingerprint is, bypassing the
+		// filtering done by WithGeneratorFingerprint
+		const fp = 2000000
+		gen := MustNewUUIDv7Generator(WithClock(clock))
+		gen.(*uuidV7Generator).std.Fingerprint = fp
+		std := newStdGenerator()
+		std.Fingerprint = fp
+
+		u, err := gen.GenerateUUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := std.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f, _ := u.V7(); f.Fingerprint != g.Fingerprint() {
+			t.Fatalf("expected fingerprint %d, got %d", g.Fingerprint(), f.Fingerprint)
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		if _, err := NewUUIDv7Generator(WithClock(nil)); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestUUIDV7(t *testing.T) {
+	u, err := TestGUID.LossyUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.V7(); err == nil {
+		t.Fatal("expected an error for a UUIDv8")
+	}
+
+	// a UUIDv7 from another implementation still decomposes
+	u, err = ParseUUID("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := u.V7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Time.UnixMilli() != 0x017f22e279b0 || f.Counter != 0xcc3 {
+		t.Fatalf("unexpected fields %+v", f)
+	}
+}